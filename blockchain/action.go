@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	txtypes "cosmossdk.io/api/cosmos/tx/v1beta1"
@@ -247,7 +248,14 @@ func (a *ActionClient) ListExpiredActions(ctx context.Context, limit, offset uin
 }
 
 // QueryActionByMetadataEnum queries actions by metadata and typed ActionType with pagination.
+// metadataQuery must use the chain's "field=value" syntax; see MetadataQuery for a typed builder.
 func (a *ActionClient) QueryActionByMetadataEnum(ctx context.Context, actionType actiontypes.ActionType, metadataQuery string, limit, offset uint64) ([]*types.Action, error) {
+	if actionType == actiontypes.ActionTypeUnspecified {
+		return nil, fmt.Errorf("action type is required")
+	}
+	if field, value, ok := strings.Cut(metadataQuery, "="); !ok || field == "" || value == "" {
+		return nil, fmt.Errorf("invalid metadata query %q: expected field=value", metadataQuery)
+	}
 	req := &actiontypes.QueryActionByMetadataRequest{
 		ActionType:    actionType,
 		MetadataQuery: metadataQuery,
@@ -270,35 +278,70 @@ func (a *ActionClient) QueryActionByMetadataEnum(ctx context.Context, actionType
 }
 
 // QueryActionByMetadata queries actions by metadata and string ActionType with pagination.
+// Unknown action types are rejected rather than falling back to UNSPECIFIED.
 func (a *ActionClient) QueryActionByMetadata(ctx context.Context, actionTypeStr, metadataQuery string, limit, offset uint64) ([]*types.Action, error) {
-	at, ok := parseActionType(actionTypeStr)
-	if !ok {
-		at = 0 // unspecified
+	field, value, _ := strings.Cut(metadataQuery, "=")
+	q := MetadataQuery().ActionType(actionTypeStr).Field(field, value)
+	if err := q.validate(); err != nil {
+		return nil, fmt.Errorf("invalid metadata query: %w", err)
 	}
-	return a.QueryActionByMetadataEnum(ctx, at, metadataQuery, limit, offset)
+	return a.QueryActionByMetadataEnum(ctx, q.actionType, metadataQuery, limit, offset)
 }
 
 // -------- Transaction Helpers --------
 
 // RequestActionTx builds, signs, broadcasts and confirms a MsgRequestAction.
 func (c *Client) RequestActionTx(ctx context.Context, creator string, actionType actiontypes.ActionType, metadata, price, expiration string, fileSizeKbs int64, memo string) (*types.ActionResult, error) {
+	txHash, err := c.BroadcastRequestActionTx(ctx, creator, actionType, metadata, price, expiration, fileSizeKbs, memo)
+	if err != nil {
+		return nil, err
+	}
+	return c.ConfirmRequestActionTx(ctx, txHash)
+}
+
+// BroadcastRequestActionTx builds, signs and broadcasts a MsgRequestAction
+// and returns its hash without waiting for inclusion. ConfirmRequestActionTx
+// completes it; callers record the hash in between to survive a crash.
+func (c *Client) BroadcastRequestActionTx(ctx context.Context, creator string, actionType actiontypes.ActionType, metadata, price, expiration string, fileSizeKbs int64, memo string) (string, error) {
 	msg := NewMsgRequestAction(creator, actionType, metadata, price, expiration, fileSizeKbs)
 
 	txBytes, err := c.BuildAndSignTx(ctx, msg, memo)
 	if err != nil {
-		return nil, fmt.Errorf("build and sign tx: %w", err)
+		return "", fmt.Errorf("build and sign tx: %w", err)
 	}
 
 	txHash, err := c.Broadcast(ctx, txBytes, txtypes.BroadcastMode_BROADCAST_MODE_SYNC)
 	if err != nil {
-		return nil, fmt.Errorf("broadcast tx: %w", err)
+		return "", fmt.Errorf("broadcast tx: %w", err)
 	}
+	return txHash, nil
+}
 
+// ConfirmRequestActionTx waits for a broadcast MsgRequestAction and returns
+// the registered action.
+func (c *Client) ConfirmRequestActionTx(ctx context.Context, txHash string) (*types.ActionResult, error) {
 	resp, err := c.WaitForTxInclusion(ctx, txHash)
 	if err != nil {
 		return nil, fmt.Errorf("wait for tx inclusion: %w", err)
 	}
+	return c.requestActionResult(txHash, resp)
+}
 
+// RequestActionTxResult looks up a MsgRequestAction tx without waiting. It
+// returns an error wrapping types.ErrNotFound when the tx is not (yet)
+// included and types.ErrTxFailed when it was included but failed.
+func (c *Client) RequestActionTxResult(ctx context.Context, txHash string) (*types.ActionResult, error) {
+	resp, err := c.GetTx(ctx, txHash)
+	if err != nil {
+		return nil, queryError("tx "+txHash, err)
+	}
+	return c.requestActionResult(txHash, resp)
+}
+
+func (c *Client) requestActionResult(txHash string, resp *txtypes.GetTxResponse) (*types.ActionResult, error) {
+	if resp.TxResponse != nil && resp.TxResponse.Code != 0 {
+		return nil, fmt.Errorf("tx %s: %w with code %d: %s", txHash, types.ErrTxFailed, resp.TxResponse.Code, resp.TxResponse.RawLog)
+	}
 	actionID, err := c.ExtractEventAttribute(resp, "action_registered", "action_id")
 	if err != nil {
 		return nil, fmt.Errorf("extract action_id: %w", err)
@@ -364,6 +407,7 @@ func (c *Client) ApproveActionTx(ctx context.Context, creator, actionID, memo st
 }
 
 // UpdateActionParamsTx builds, signs, broadcasts and confirms a MsgUpdateParams for the Action module.
+// On a live chain the authority is the gov module; use ProposeActionParams there.
 func (c *Client) UpdateActionParamsTx(ctx context.Context, authority string, params actiontypes.Params, memo string) (*types.ActionResult, error) {
 	msg := NewMsgUpdateParams(authority, params)
 
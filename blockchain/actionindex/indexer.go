@@ -0,0 +1,173 @@
+// Package actionindex maintains an optional local, on-disk index of Lumera
+// actions. It backfills through the action list queries, then follows new
+// blocks and their action events so rich queries can be answered offline.
+package actionindex
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/LumeraProtocol/sdk-go/types"
+)
+
+// Config tunes the Indexer.
+type Config struct {
+	// PageSize is the page size used while backfilling (default 100).
+	PageSize uint64
+	// PollInterval controls how often new blocks are checked (default 5s).
+	PollInterval time.Duration
+	// Logger is optional.
+	Logger *zap.Logger
+}
+
+// Indexer keeps a Store in sync with the chain.
+type Indexer struct {
+	store  *Store
+	source Source
+	cfg    Config
+}
+
+// New creates an Indexer writing into store and reading from source.
+func New(store *Store, source Source, cfg Config) (*Indexer, error) {
+	if store == nil || source == nil {
+		return nil, fmt.Errorf("store and source are required")
+	}
+	if cfg.PageSize == 0 {
+		cfg.PageSize = 100
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = 5 * time.Second
+	}
+	return &Indexer{store: store, source: source, cfg: cfg}, nil
+}
+
+// Store returns the underlying store for queries.
+func (ix *Indexer) Store() *Store {
+	return ix.store
+}
+
+// Query is a shortcut for Store().Query.
+func (ix *Indexer) Query(q Query) ([]*types.Action, error) {
+	return ix.store.Query(q)
+}
+
+// Backfill pages through every action on chain and stores it. The checkpoint
+// is set to the height observed before the scan started, so changes made while
+// backfilling are replayed by SyncOnce.
+func (ix *Indexer) Backfill(ctx context.Context) error {
+	height, err := ix.source.LatestHeight(ctx)
+	if err != nil {
+		return err
+	}
+	for offset := uint64(0); ; offset += ix.cfg.PageSize {
+		actions, err := ix.source.ListActions(ctx, ix.cfg.PageSize, offset)
+		if err != nil {
+			return fmt.Errorf("backfill at offset %d: %w", offset, err)
+		}
+		if err := ix.store.Put(actions...); err != nil {
+			return err
+		}
+		if uint64(len(actions)) < ix.cfg.PageSize {
+			break
+		}
+	}
+	ix.logf("actionindex: backfill complete at height %d", height)
+	return ix.store.SetCheckpoint(height)
+}
+
+// SyncOnce indexes every block between the checkpoint and the latest height,
+// then refreshes in-flight actions whose expiration has passed (expiry is
+// applied in EndBlock and emits no tx events). It backfills first when the
+// store has no checkpoint.
+func (ix *Indexer) SyncOnce(ctx context.Context) error {
+	checkpoint, err := ix.store.Checkpoint()
+	if err != nil {
+		return err
+	}
+	if checkpoint == 0 {
+		return ix.Backfill(ctx)
+	}
+
+	latest, err := ix.source.LatestHeight(ctx)
+	if err != nil {
+		return err
+	}
+	for h := checkpoint + 1; h <= latest; h++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		ids, err := ix.source.ActionIDsInBlock(ctx, h)
+		if err != nil {
+			return fmt.Errorf("index block %d: %w", h, err)
+		}
+		if err := ix.refresh(ctx, ids); err != nil {
+			return fmt.Errorf("index block %d: %w", h, err)
+		}
+		if err := ix.store.SetCheckpoint(h); err != nil {
+			return err
+		}
+	}
+	return ix.refreshExpired(ctx)
+}
+
+// Run syncs until ctx is cancelled, polling for new blocks every PollInterval.
+// Transient errors are logged and retried on the next tick.
+func (ix *Indexer) Run(ctx context.Context) error {
+	ticker := time.NewTicker(ix.cfg.PollInterval)
+	defer ticker.Stop()
+	for {
+		if err := ix.SyncOnce(ctx); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			ix.logf("actionindex: sync failed: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (ix *Indexer) refresh(ctx context.Context, ids []string) error {
+	actions := make([]*types.Action, 0, len(ids))
+	for _, id := range ids {
+		action, err := ix.source.GetAction(ctx, id)
+		if err != nil {
+			return err
+		}
+		if action != nil {
+			actions = append(actions, action)
+		}
+	}
+	return ix.store.Put(actions...)
+}
+
+func (ix *Indexer) refreshExpired(ctx context.Context) error {
+	now := time.Now()
+	var ids []string
+	for _, state := range []types.ActionState{types.ActionStatePending, types.ActionStateProcessing} {
+		actions, err := ix.store.Query(Query{State: state, ExpiresBefore: now})
+		if err != nil {
+			return err
+		}
+		for _, a := range actions {
+			ids = append(ids, a.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	return ix.refresh(ctx, ids)
+}
+
+func (ix *Indexer) logf(format string, args ...interface{}) {
+	if ix.cfg.Logger == nil {
+		return
+	}
+	ix.cfg.Logger.Info(fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,138 @@
+package actionindex
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	dbm "github.com/cosmos/cosmos-db"
+	"github.com/stretchr/testify/require"
+
+	"github.com/LumeraProtocol/sdk-go/types"
+)
+
+type fakeSource struct {
+	height  int64
+	actions map[string]*types.Action
+	order   []string
+	blocks  map[int64][]string
+}
+
+func newFakeSource() *fakeSource {
+	return &fakeSource{actions: map[string]*types.Action{}, blocks: map[int64][]string{}}
+}
+
+func (f *fakeSource) add(a *types.Action) {
+	if _, ok := f.actions[a.ID]; !ok {
+		f.order = append(f.order, a.ID)
+	}
+	f.actions[a.ID] = a
+}
+
+func (f *fakeSource) LatestHeight(context.Context) (int64, error) { return f.height, nil }
+
+func (f *fakeSource) ListActions(_ context.Context, limit, offset uint64) ([]*types.Action, error) {
+	var out []*types.Action
+	for i := offset; i < uint64(len(f.order)) && uint64(len(out)) < limit; i++ {
+		out = append(out, f.actions[f.order[i]])
+	}
+	return out, nil
+}
+
+func (f *fakeSource) GetAction(_ context.Context, id string) (*types.Action, error) {
+	return f.actions[id], nil
+}
+
+func (f *fakeSource) ActionIDsInBlock(_ context.Context, height int64) ([]string, error) {
+	return f.blocks[height], nil
+}
+
+func cascadeAction(id, creator string, state types.ActionState, height int64, hash string) *types.Action {
+	return &types.Action{
+		ID:             id,
+		Creator:        creator,
+		Type:           types.ActionTypeCascade,
+		State:          state,
+		BlockHeight:    height,
+		ExpirationTime: time.Now().Add(time.Hour),
+		Metadata:       &types.CascadeMetadata{DataHash: hash},
+	}
+}
+
+func TestIndexerBackfillFollowAndQuery(t *testing.T) {
+	ctx := context.Background()
+	src := newFakeSource()
+	src.height = 10
+	for i, creator := range []string{"alice", "bob", "alice"} {
+		id := string(rune('1' + i))
+		src.add(cascadeAction(id, creator, types.ActionStatePending, int64(i+1), "hash-"+id))
+	}
+
+	store := NewStore(dbm.NewMemDB())
+	ix, err := New(store, src, Config{PageSize: 2})
+	require.NoError(t, err)
+
+	require.NoError(t, ix.SyncOnce(ctx))
+	cp, err := store.Checkpoint()
+	require.NoError(t, err)
+	require.Equal(t, int64(10), cp)
+
+	got, err := ix.Query(Query{Creator: "alice", State: types.ActionStatePending})
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	require.Equal(t, "1", got[0].ID)
+	require.Equal(t, "3", got[1].ID)
+
+	// Block 11 finalizes action 1 and registers action 4.
+	src.height = 11
+	src.add(cascadeAction("1", "alice", types.ActionStateDone, 1, "hash-1"))
+	src.add(cascadeAction("4", "bob", types.ActionStatePending, 11, "hash-4"))
+	src.blocks[11] = []string{"1", "4"}
+	require.NoError(t, ix.SyncOnce(ctx))
+
+	got, err = ix.Query(Query{Creator: "alice", State: types.ActionStatePending})
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	require.Equal(t, "3", got[0].ID)
+
+	got, err = ix.Query(Query{State: types.ActionStateDone})
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+
+	got, err = ix.Query(Query{DataHash: "hash-4"})
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	require.Equal(t, "bob", got[0].Creator)
+
+	got, err = ix.Query(Query{MinHeight: 2, MaxHeight: 11, Limit: 1, Offset: 1})
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	require.Equal(t, "3", got[0].ID)
+
+	cp, err = store.Checkpoint()
+	require.NoError(t, err)
+	require.Equal(t, int64(11), cp)
+}
+
+func TestIndexerRefreshesExpiredActions(t *testing.T) {
+	ctx := context.Background()
+	src := newFakeSource()
+	src.height = 5
+	expiring := cascadeAction("1", "alice", types.ActionStatePending, 1, "h")
+	expiring.ExpirationTime = time.Now().Add(-time.Minute)
+	src.add(expiring)
+
+	store := NewStore(dbm.NewMemDB())
+	ix, err := New(store, src, Config{})
+	require.NoError(t, err)
+	require.NoError(t, ix.SyncOnce(ctx))
+
+	expired := *expiring
+	expired.State = types.ActionStateExpired
+	src.add(&expired)
+	require.NoError(t, ix.SyncOnce(ctx))
+
+	got, err := store.Get("1")
+	require.NoError(t, err)
+	require.Equal(t, types.ActionStateExpired, got.State)
+}
@@ -0,0 +1,90 @@
+package actionindex
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/client/grpc/cmtservice"
+
+	"github.com/LumeraProtocol/sdk-go/blockchain"
+	"github.com/LumeraProtocol/sdk-go/types"
+)
+
+// Source is the chain access required by the Indexer.
+type Source interface {
+	// LatestHeight returns the latest committed block height.
+	LatestHeight(ctx context.Context) (int64, error)
+	// ListActions returns a page of actions.
+	ListActions(ctx context.Context, limit, offset uint64) ([]*types.Action, error)
+	// GetAction returns a single action by ID.
+	GetAction(ctx context.Context, actionID string) (*types.Action, error)
+	// ActionIDsInBlock returns the IDs of actions touched by txs in the block at height.
+	ActionIDsInBlock(ctx context.Context, height int64) ([]string, error)
+}
+
+// chainSource implements Source on top of a Lumera blockchain client.
+type chainSource struct {
+	bc *blockchain.Client
+}
+
+// NewChainSource adapts a blockchain client to the Source interface.
+func NewChainSource(bc *blockchain.Client) Source {
+	return &chainSource{bc: bc}
+}
+
+func (s *chainSource) LatestHeight(ctx context.Context) (int64, error) {
+	resp, err := cmtservice.NewServiceClient(s.bc.GRPCConn()).GetLatestBlock(ctx, &cmtservice.GetLatestBlockRequest{})
+	if err != nil {
+		return 0, fmt.Errorf("get latest block: %w", err)
+	}
+	if resp.SdkBlock != nil {
+		return resp.SdkBlock.Header.Height, nil
+	}
+	if resp.Block != nil {
+		return resp.Block.Header.Height, nil
+	}
+	return 0, fmt.Errorf("empty latest block response")
+}
+
+func (s *chainSource) ListActions(ctx context.Context, limit, offset uint64) ([]*types.Action, error) {
+	return s.bc.Action.ListActions(ctx, blockchain.WithPagination(limit, offset))
+}
+
+func (s *chainSource) GetAction(ctx context.Context, actionID string) (*types.Action, error) {
+	return s.bc.Action.GetAction(ctx, actionID)
+}
+
+func (s *chainSource) ActionIDsInBlock(ctx context.Context, height int64) ([]string, error) {
+	const pageSize = 100
+	seen := make(map[string]struct{})
+	var ids []string
+	for page := uint64(1); ; page++ {
+		resp, err := s.bc.GetTxsByEvents(ctx, []string{fmt.Sprintf("tx.height=%d", height)}, page, pageSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, tx := range resp.TxResponses {
+			if tx == nil || tx.Code != 0 {
+				continue
+			}
+			for _, ev := range tx.Events {
+				if ev == nil || !strings.HasPrefix(ev.GetType_(), "action_") {
+					continue
+				}
+				for _, attr := range ev.GetAttributes() {
+					if attr == nil || attr.GetKey() != "action_id" || attr.GetValue() == "" {
+						continue
+					}
+					if _, ok := seen[attr.GetValue()]; !ok {
+						seen[attr.GetValue()] = struct{}{}
+						ids = append(ids, attr.GetValue())
+					}
+				}
+			}
+		}
+		if uint64(len(resp.TxResponses)) < pageSize || page*pageSize >= resp.Total {
+			return ids, nil
+		}
+	}
+}
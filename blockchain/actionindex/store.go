@@ -0,0 +1,307 @@
+package actionindex
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	dbm "github.com/cosmos/cosmos-db"
+
+	"github.com/LumeraProtocol/sdk-go/types"
+)
+
+// Key layout. Secondary index keys are prefix | value | 0x00 | actionID and
+// carry an empty value; the action itself lives under prefixAction.
+var (
+	prefixAction     = []byte{0x01}
+	prefixByCreator  = []byte{0x02}
+	prefixByState    = []byte{0x03}
+	prefixByType     = []byte{0x04}
+	prefixByDataHash = []byte{0x05}
+	keyCheckpoint    = []byte{0x10}
+)
+
+const keySep = 0x00
+
+// Query selects actions from the local index. Zero-valued fields are ignored.
+type Query struct {
+	Creator  string
+	State    types.ActionState
+	Type     types.ActionType
+	DataHash string
+
+	// MinHeight and MaxHeight bound the registration block height (inclusive).
+	MinHeight int64
+	MaxHeight int64
+
+	// ExpiresAfter and ExpiresBefore bound the action expiration time.
+	ExpiresAfter  time.Time
+	ExpiresBefore time.Time
+
+	// Offset and Limit page through the results, ordered by block height then ID.
+	// A zero Limit returns all matches.
+	Offset int
+	Limit  int
+}
+
+// Store persists actions and their secondary indexes in an embedded key/value database.
+type Store struct {
+	db dbm.DB
+}
+
+// NewStore wraps an existing database. The caller owns the database lifecycle
+// unless Close is called on the store.
+func NewStore(db dbm.DB) *Store {
+	return &Store{db: db}
+}
+
+// OpenStore opens (or creates) a LevelDB-backed store named name inside dir.
+func OpenStore(name, dir string) (*Store, error) {
+	db, err := dbm.NewGoLevelDB(name, dir, nil)
+	if err != nil {
+		return nil, fmt.Errorf("open action index: %w", err)
+	}
+	return NewStore(db), nil
+}
+
+// Close closes the underlying database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Checkpoint returns the last fully indexed block height (0 when empty).
+func (s *Store) Checkpoint() (int64, error) {
+	bz, err := s.db.Get(keyCheckpoint)
+	if err != nil {
+		return 0, fmt.Errorf("read checkpoint: %w", err)
+	}
+	if len(bz) != 8 {
+		return 0, nil
+	}
+	return int64(binary.BigEndian.Uint64(bz)), nil
+}
+
+// SetCheckpoint records the last fully indexed block height.
+func (s *Store) SetCheckpoint(height int64) error {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, uint64(height))
+	if err := s.db.SetSync(keyCheckpoint, bz); err != nil {
+		return fmt.Errorf("write checkpoint: %w", err)
+	}
+	return nil
+}
+
+// Get returns the indexed action or nil when absent.
+func (s *Store) Get(actionID string) (*types.Action, error) {
+	bz, err := s.db.Get(actionKey(actionID))
+	if err != nil {
+		return nil, fmt.Errorf("read action %s: %w", actionID, err)
+	}
+	if bz == nil {
+		return nil, nil
+	}
+	var action types.Action
+	if err := json.Unmarshal(bz, &action); err != nil {
+		return nil, fmt.Errorf("decode action %s: %w", actionID, err)
+	}
+	return &action, nil
+}
+
+// Put inserts or replaces actions, keeping secondary indexes in sync.
+func (s *Store) Put(actions ...*types.Action) error {
+	batch := s.db.NewBatch()
+	defer batch.Close() //nolint:errcheck
+
+	for _, action := range actions {
+		if action == nil || action.ID == "" {
+			continue
+		}
+		prev, err := s.Get(action.ID)
+		if err != nil {
+			return err
+		}
+		if prev != nil {
+			for _, k := range indexKeys(prev) {
+				if err := batch.Delete(k); err != nil {
+					return fmt.Errorf("delete index entry: %w", err)
+				}
+			}
+		}
+		bz, err := json.Marshal(action)
+		if err != nil {
+			return fmt.Errorf("encode action %s: %w", action.ID, err)
+		}
+		if err := batch.Set(actionKey(action.ID), bz); err != nil {
+			return fmt.Errorf("write action %s: %w", action.ID, err)
+		}
+		for _, k := range indexKeys(action) {
+			if err := batch.Set(k, []byte{}); err != nil {
+				return fmt.Errorf("write index entry: %w", err)
+			}
+		}
+	}
+	if err := batch.WriteSync(); err != nil {
+		return fmt.Errorf("commit actions: %w", err)
+	}
+	return nil
+}
+
+// Query returns the actions matching q, ordered by block height then ID.
+func (s *Store) Query(q Query) ([]*types.Action, error) {
+	ids, err := s.candidateIDs(q)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []*types.Action
+	for _, id := range ids {
+		action, err := s.Get(id)
+		if err != nil {
+			return nil, err
+		}
+		if action != nil && q.matches(action) {
+			out = append(out, action)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].BlockHeight != out[j].BlockHeight {
+			return out[i].BlockHeight < out[j].BlockHeight
+		}
+		return lessID(out[i].ID, out[j].ID)
+	})
+
+	if q.Offset > 0 {
+		if q.Offset >= len(out) {
+			return nil, nil
+		}
+		out = out[q.Offset:]
+	}
+	if q.Limit > 0 && len(out) > q.Limit {
+		out = out[:q.Limit]
+	}
+	return out, nil
+}
+
+// candidateIDs scans the most selective index available for q, falling back
+// to a full scan of stored actions.
+func (s *Store) candidateIDs(q Query) ([]string, error) {
+	switch {
+	case q.DataHash != "":
+		return s.scanIndex(prefixByDataHash, q.DataHash)
+	case q.Creator != "":
+		return s.scanIndex(prefixByCreator, q.Creator)
+	case q.State != "":
+		return s.scanIndex(prefixByState, string(q.State))
+	case q.Type != "":
+		return s.scanIndex(prefixByType, string(q.Type))
+	}
+
+	it, err := s.db.Iterator(prefixAction, prefixEnd(prefixAction))
+	if err != nil {
+		return nil, fmt.Errorf("scan actions: %w", err)
+	}
+	defer it.Close() //nolint:errcheck
+
+	var ids []string
+	for ; it.Valid(); it.Next() {
+		ids = append(ids, string(it.Key()[len(prefixAction):]))
+	}
+	return ids, it.Error()
+}
+
+func (s *Store) scanIndex(prefix []byte, value string) ([]string, error) {
+	start := indexPrefix(prefix, value)
+	it, err := s.db.Iterator(start, prefixEnd(start))
+	if err != nil {
+		return nil, fmt.Errorf("scan index: %w", err)
+	}
+	defer it.Close() //nolint:errcheck
+
+	var ids []string
+	for ; it.Valid(); it.Next() {
+		ids = append(ids, string(it.Key()[len(start):]))
+	}
+	return ids, it.Error()
+}
+
+func (q Query) matches(a *types.Action) bool {
+	if q.Creator != "" && a.Creator != q.Creator {
+		return false
+	}
+	if q.State != "" && a.State != q.State {
+		return false
+	}
+	if q.Type != "" && a.Type != q.Type {
+		return false
+	}
+	if q.DataHash != "" && dataHash(a) != q.DataHash {
+		return false
+	}
+	if q.MinHeight > 0 && a.BlockHeight < q.MinHeight {
+		return false
+	}
+	if q.MaxHeight > 0 && a.BlockHeight > q.MaxHeight {
+		return false
+	}
+	if !q.ExpiresAfter.IsZero() && !a.ExpirationTime.After(q.ExpiresAfter) {
+		return false
+	}
+	if !q.ExpiresBefore.IsZero() && !a.ExpirationTime.Before(q.ExpiresBefore) {
+		return false
+	}
+	return true
+}
+
+func dataHash(a *types.Action) string {
+	switch m := a.Metadata.(type) {
+	case *types.CascadeMetadata:
+		return m.DataHash
+	case *types.SenseMetadata:
+		return m.DataHash
+	}
+	return ""
+}
+
+func actionKey(id string) []byte {
+	return append(append([]byte{}, prefixAction...), id...)
+}
+
+func indexPrefix(prefix []byte, value string) []byte {
+	k := append(append([]byte{}, prefix...), value...)
+	return append(k, keySep)
+}
+
+func indexKeys(a *types.Action) [][]byte {
+	keys := [][]byte{
+		append(indexPrefix(prefixByCreator, a.Creator), a.ID...),
+		append(indexPrefix(prefixByState, string(a.State)), a.ID...),
+		append(indexPrefix(prefixByType, string(a.Type)), a.ID...),
+	}
+	if h := dataHash(a); h != "" {
+		keys = append(keys, append(indexPrefix(prefixByDataHash, h), a.ID...))
+	}
+	return keys
+}
+
+// prefixEnd returns the smallest key greater than every key starting with prefix.
+func prefixEnd(prefix []byte) []byte {
+	end := bytes.Clone(prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		end[i]++
+		if end[i] != 0 {
+			return end[:i+1]
+		}
+	}
+	return nil
+}
+
+// lessID orders IDs by length and then lexically, so numeric action IDs sort numerically.
+func lessID(a, b string) bool {
+	if len(a) != len(b) {
+		return len(a) < len(b)
+	}
+	return a < b
+}
@@ -1,12 +1,270 @@
 package blockchain
 
 import (
-// audittypes "github.com/LumeraProtocol/lumera/x/audit/types"
+	"context"
+	"fmt"
+
+	audittypes "github.com/LumeraProtocol/lumera/x/audit/v1/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+
+	"github.com/LumeraProtocol/sdk-go/types"
 )
 
+// -------- Message Constructors --------
+
+// NewAuditMsgUpdateParams constructs an audit MsgUpdateParams.
+func NewAuditMsgUpdateParams(authority string, params audittypes.Params) *audittypes.MsgUpdateParams {
+	return &audittypes.MsgUpdateParams{
+		Authority: authority,
+		Params:    params,
+	}
+}
+
+// NewMsgSubmitEvidence constructs a MsgSubmitEvidence. metadataJSON is the
+// JSON form of the metadata message matching evidenceType.
+func NewMsgSubmitEvidence(creator, subjectAddress string, evidenceType audittypes.EvidenceType, actionID, metadataJSON string) *audittypes.MsgSubmitEvidence {
+	return &audittypes.MsgSubmitEvidence{
+		Creator:        creator,
+		SubjectAddress: subjectAddress,
+		EvidenceType:   evidenceType,
+		ActionId:       actionID,
+		Metadata:       metadataJSON,
+	}
+}
+
+// NewMsgSubmitEpochReport constructs a MsgSubmitEpochReport.
+func NewMsgSubmitEpochReport(creator string, epochID uint64, hostReport audittypes.HostReport, observations []*audittypes.StorageChallengeObservation) *audittypes.MsgSubmitEpochReport {
+	return &audittypes.MsgSubmitEpochReport{
+		Creator:                      creator,
+		EpochId:                      epochID,
+		HostReport:                   hostReport,
+		StorageChallengeObservations: observations,
+	}
+}
+
 // AuditClient provides audit module operations
 type AuditClient struct {
-	//query audittypes.QueryClient
+	query audittypes.QueryClient
+}
+
+// AuditQueryOption narrows epoch-scoped audit queries.
+type AuditQueryOption func(*auditQuery)
+
+type auditQuery struct {
+	epochID  uint64
+	filtered bool
+}
+
+// WithAuditEpoch restricts a query to one epoch. Without it, AssignedTargets
+// uses the current epoch and list queries span all epochs.
+func WithAuditEpoch(epochID uint64) AuditQueryOption {
+	return func(q *auditQuery) {
+		q.epochID = epochID
+		q.filtered = true
+	}
+}
+
+func newAuditQuery(opts []AuditQueryOption) auditQuery {
+	var q auditQuery
+	for _, opt := range opts {
+		opt(&q)
+	}
+	return q
+}
+
+// Params retrieves the audit module parameters.
+func (a *AuditClient) Params(ctx context.Context) (*audittypes.Params, error) {
+	resp, err := a.query.Params(ctx, &audittypes.QueryParamsRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get audit params: %w", err)
+	}
+	if resp == nil {
+		return nil, fmt.Errorf("empty params response")
+	}
+	return &resp.Params, nil
+}
+
+// GetEvidence retrieves an evidence record by ID.
+func (a *AuditClient) GetEvidence(ctx context.Context, evidenceID uint64) (*types.AuditEvidence, error) {
+	resp, err := a.query.EvidenceById(ctx, &audittypes.QueryEvidenceByIdRequest{EvidenceId: evidenceID})
+	if err != nil {
+		return nil, queryError("evidence", err)
+	}
+	return types.AuditEvidenceFromProto(&resp.Evidence), nil
+}
+
+// ListEvidenceBySubject returns a paginated list of evidence about a subject address.
+func (a *AuditClient) ListEvidenceBySubject(ctx context.Context, subjectAddress string, limit, offset uint64) ([]*types.AuditEvidence, error) {
+	resp, err := a.query.EvidenceBySubject(ctx, &audittypes.QueryEvidenceBySubjectRequest{
+		SubjectAddress: subjectAddress,
+		Pagination:     &query.PageRequest{Limit: limit, Offset: offset},
+	})
+	if err != nil {
+		return nil, queryError("evidence by subject", err)
+	}
+	return types.AuditEvidenceListFromProto(resp.Evidence), nil
+}
+
+// ListEvidenceByAction returns a paginated list of evidence about an action.
+func (a *AuditClient) ListEvidenceByAction(ctx context.Context, actionID string, limit, offset uint64) ([]*types.AuditEvidence, error) {
+	resp, err := a.query.EvidenceByAction(ctx, &audittypes.QueryEvidenceByActionRequest{
+		ActionId:   actionID,
+		Pagination: &query.PageRequest{Limit: limit, Offset: offset},
+	})
+	if err != nil {
+		return nil, queryError("evidence by action", err)
+	}
+	return types.AuditEvidenceListFromProto(resp.Evidence), nil
+}
+
+// CurrentEpoch returns the boundaries of the current audit epoch.
+func (a *AuditClient) CurrentEpoch(ctx context.Context) (*types.EpochInfo, error) {
+	resp, err := a.query.CurrentEpoch(ctx, &audittypes.QueryCurrentEpochRequest{})
+	if err != nil {
+		return nil, queryError("current epoch", err)
+	}
+	return &types.EpochInfo{ID: resp.EpochId, StartHeight: resp.EpochStartHeight, EndHeight: resp.EpochEndHeight}, nil
+}
+
+// GetEpochAnchor retrieves the persisted anchor of an epoch.
+func (a *AuditClient) GetEpochAnchor(ctx context.Context, epochID uint64) (*types.EpochAnchor, error) {
+	resp, err := a.query.EpochAnchor(ctx, &audittypes.QueryEpochAnchorRequest{EpochId: epochID})
+	if err != nil {
+		return nil, queryError("epoch anchor", err)
+	}
+	return types.EpochAnchorFromProto(&resp.Anchor), nil
+}
+
+// CurrentEpochAnchor retrieves the anchor of the current epoch.
+func (a *AuditClient) CurrentEpochAnchor(ctx context.Context) (*types.EpochAnchor, error) {
+	resp, err := a.query.CurrentEpochAnchor(ctx, &audittypes.QueryCurrentEpochAnchorRequest{})
+	if err != nil {
+		return nil, queryError("current epoch anchor", err)
+	}
+	return types.EpochAnchorFromProto(&resp.Anchor), nil
+}
+
+// AssignedTargets returns the supernodes a prober account must check.
+func (a *AuditClient) AssignedTargets(ctx context.Context, supernodeAccount string, opts ...AuditQueryOption) (*types.AssignedTargets, error) {
+	q := newAuditQuery(opts)
+	resp, err := a.query.AssignedTargets(ctx, &audittypes.QueryAssignedTargetsRequest{
+		SupernodeAccount: supernodeAccount,
+		EpochId:          q.epochID,
+		FilterByEpochId:  q.filtered,
+	})
+	if err != nil {
+		return nil, queryError("assigned targets", err)
+	}
+	return &types.AssignedTargets{
+		EpochID:           resp.EpochId,
+		EpochStartHeight:  resp.EpochStartHeight,
+		RequiredOpenPorts: resp.RequiredOpenPorts,
+		Targets:           resp.TargetSupernodeAccounts,
+	}, nil
 }
 
-// Add audit-specific methods here as needed
+// GetEpochReport retrieves the report a supernode account submitted for an epoch.
+func (a *AuditClient) GetEpochReport(ctx context.Context, epochID uint64, supernodeAccount string) (*types.EpochReport, error) {
+	resp, err := a.query.EpochReport(ctx, &audittypes.QueryEpochReportRequest{
+		EpochId:          epochID,
+		SupernodeAccount: supernodeAccount,
+	})
+	if err != nil {
+		return nil, queryError("epoch report", err)
+	}
+	return types.EpochReportFromProto(&resp.Report), nil
+}
+
+// ListEpochReportsByReporter returns a paginated list of epoch reports
+// submitted by a supernode account.
+func (a *AuditClient) ListEpochReportsByReporter(ctx context.Context, supernodeAccount string, limit, offset uint64, opts ...AuditQueryOption) ([]*types.EpochReport, error) {
+	q := newAuditQuery(opts)
+	resp, err := a.query.EpochReportsByReporter(ctx, &audittypes.QueryEpochReportsByReporterRequest{
+		SupernodeAccount: supernodeAccount,
+		EpochId:          q.epochID,
+		FilterByEpochId:  q.filtered,
+		Pagination:       &query.PageRequest{Limit: limit, Offset: offset},
+	})
+	if err != nil {
+		return nil, queryError("epoch reports", err)
+	}
+	out := make([]*types.EpochReport, 0, len(resp.Reports))
+	for i := range resp.Reports {
+		out = append(out, types.EpochReportFromProto(&resp.Reports[i]))
+	}
+	return out, nil
+}
+
+// ListStorageChallengeReports returns a paginated list of reports containing
+// storage challenge observations about a supernode account.
+func (a *AuditClient) ListStorageChallengeReports(ctx context.Context, supernodeAccount string, limit, offset uint64, opts ...AuditQueryOption) ([]types.StorageChallengeReport, error) {
+	q := newAuditQuery(opts)
+	resp, err := a.query.StorageChallengeReports(ctx, &audittypes.QueryStorageChallengeReportsRequest{
+		SupernodeAccount: supernodeAccount,
+		EpochId:          q.epochID,
+		FilterByEpochId:  q.filtered,
+		Pagination:       &query.PageRequest{Limit: limit, Offset: offset},
+	})
+	if err != nil {
+		return nil, queryError("storage challenge reports", err)
+	}
+	out := make([]types.StorageChallengeReport, 0, len(resp.Reports))
+	for _, r := range resp.Reports {
+		out = append(out, types.StorageChallengeReportFromProto(r))
+	}
+	return out, nil
+}
+
+// ListHostReports returns a paginated list of host reports submitted by a
+// supernode account.
+func (a *AuditClient) ListHostReports(ctx context.Context, supernodeAccount string, limit, offset uint64, opts ...AuditQueryOption) ([]types.HostReportEntry, error) {
+	q := newAuditQuery(opts)
+	resp, err := a.query.HostReports(ctx, &audittypes.QueryHostReportsRequest{
+		SupernodeAccount: supernodeAccount,
+		EpochId:          q.epochID,
+		FilterByEpochId:  q.filtered,
+		Pagination:       &query.PageRequest{Limit: limit, Offset: offset},
+	})
+	if err != nil {
+		return nil, queryError("host reports", err)
+	}
+	out := make([]types.HostReportEntry, 0, len(resp.Reports))
+	for _, r := range resp.Reports {
+		out = append(out, types.HostReportEntryFromProto(r))
+	}
+	return out, nil
+}
+
+// -------- Transaction Helpers --------
+
+// UpdateAuditParamsTx builds, signs, broadcasts and confirms an audit MsgUpdateParams.
+func (c *Client) UpdateAuditParamsTx(ctx context.Context, authority string, params audittypes.Params, memo string) (*types.ActionResult, error) {
+	resp, txHash, err := c.sendMsg(ctx, NewAuditMsgUpdateParams(authority, params), memo)
+	if err != nil {
+		return nil, err
+	}
+	return &types.ActionResult{TxHash: txHash, Height: resp.TxResponse.Height}, nil
+}
+
+// SubmitEpochReportTx submits a supernode's report for an epoch.
+func (c *Client) SubmitEpochReportTx(ctx context.Context, creator string, epochID uint64, hostReport audittypes.HostReport, observations []*audittypes.StorageChallengeObservation, memo string) (*types.ActionResult, error) {
+	resp, txHash, err := c.sendMsg(ctx, NewMsgSubmitEpochReport(creator, epochID, hostReport, observations), memo)
+	if err != nil {
+		return nil, err
+	}
+	return &types.ActionResult{TxHash: txHash, Height: resp.TxResponse.Height}, nil
+}
+
+// SubmitEvidenceTx submits evidence and returns the chain-assigned evidence ID.
+func (c *Client) SubmitEvidenceTx(ctx context.Context, creator, subjectAddress string, evidenceType audittypes.EvidenceType, actionID, metadataJSON, memo string) (*types.EvidenceResult, error) {
+	msg := NewMsgSubmitEvidence(creator, subjectAddress, evidenceType, actionID, metadataJSON)
+	resp, txHash, err := c.sendMsg(ctx, msg, memo)
+	if err != nil {
+		return nil, err
+	}
+	var out audittypes.MsgSubmitEvidenceResponse
+	if err := decodeMsgResponse(resp.TxResponse.Data, "MsgSubmitEvidenceResponse", &out); err != nil {
+		return nil, fmt.Errorf("extract evidence id: %w", err)
+	}
+	return &types.EvidenceResult{TxHash: txHash, Height: resp.TxResponse.Height, EvidenceID: out.EvidenceId}, nil
+}
@@ -0,0 +1,39 @@
+package blockchain
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	audittypes "github.com/LumeraProtocol/lumera/x/audit/v1/types"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	gogoproto "github.com/cosmos/gogoproto/proto"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/LumeraProtocol/sdk-go/types"
+)
+
+func TestDecodeMsgResponse(t *testing.T) {
+	anyResp, err := codectypes.NewAnyWithValue(&audittypes.MsgSubmitEvidenceResponse{EvidenceId: 42})
+	require.NoError(t, err)
+	bz, err := gogoproto.Marshal(&sdk.TxMsgData{MsgResponses: []*codectypes.Any{anyResp}})
+	require.NoError(t, err)
+
+	var out audittypes.MsgSubmitEvidenceResponse
+	require.NoError(t, decodeMsgResponse(hex.EncodeToString(bz), "MsgSubmitEvidenceResponse", &out))
+	require.Equal(t, uint64(42), out.EvidenceId)
+
+	require.ErrorContains(t, decodeMsgResponse(hex.EncodeToString(bz), "MsgOtherResponse", &out), "no MsgOtherResponse")
+	require.Error(t, decodeMsgResponse("zz", "MsgSubmitEvidenceResponse", &out))
+}
+
+func TestQueryError(t *testing.T) {
+	err := queryError("evidence", status.Error(codes.NotFound, "evidence not found"))
+	require.True(t, errors.Is(err, types.ErrNotFound))
+
+	err = queryError("evidence", status.Error(codes.Unavailable, "down"))
+	require.False(t, errors.Is(err, types.ErrNotFound))
+}
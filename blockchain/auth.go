@@ -0,0 +1,199 @@
+package blockchain
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/client/grpc/cmtservice"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	vestexported "github.com/cosmos/cosmos-sdk/x/auth/vesting/exported"
+	vestingtypes "github.com/cosmos/cosmos-sdk/x/auth/vesting/types"
+
+	"github.com/LumeraProtocol/sdk-go/constants"
+	sdkcrypto "github.com/LumeraProtocol/sdk-go/pkg/crypto"
+	"github.com/LumeraProtocol/sdk-go/types"
+)
+
+// AuthClient provides auth module operations
+type AuthClient struct {
+	query    authtypes.QueryClient
+	bank     *BankClient
+	cmt      cmtservice.ServiceClient
+	registry codectypes.InterfaceRegistry
+}
+
+func newAuthClient(query authtypes.QueryClient, bank *BankClient, cmt cmtservice.ServiceClient) *AuthClient {
+	return &AuthClient{query: query, bank: bank, cmt: cmt, registry: sdkcrypto.NewInterfaceRegistry()}
+}
+
+// GetAccount retrieves and decodes an account, including vesting and module
+// accounts. Unknown addresses return types.ErrNotFound.
+func (a *AuthClient) GetAccount(ctx context.Context, address string) (*types.Account, error) {
+	acc, typeURL, err := a.account(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	return accountFromSDK(acc, typeURL), nil
+}
+
+// GetModuleAccount retrieves a module account by module name.
+func (a *AuthClient) GetModuleAccount(ctx context.Context, name string) (*types.Account, error) {
+	resp, err := a.query.ModuleAccountByName(ctx, &authtypes.QueryModuleAccountByNameRequest{Name: name})
+	if err != nil {
+		return nil, queryError("module account", err)
+	}
+	acc, err := a.unpack(resp.Account)
+	if err != nil {
+		return nil, err
+	}
+	return accountFromSDK(acc, resp.Account.TypeUrl), nil
+}
+
+// Params retrieves the auth module parameters.
+func (a *AuthClient) Params(ctx context.Context) (*authtypes.Params, error) {
+	resp, err := a.query.Params(ctx, &authtypes.QueryParamsRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get auth params: %w", err)
+	}
+	return &resp.Params, nil
+}
+
+// Balances returns the balance of address split into spendable and locked
+// parts at the latest block time.
+func (a *AuthClient) Balances(ctx context.Context, address string) (*types.AccountBalance, error) {
+	resp, err := a.cmt.GetLatestBlock(ctx, &cmtservice.GetLatestBlockRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest block: %w", err)
+	}
+	if resp.SdkBlock == nil {
+		return nil, fmt.Errorf("empty latest block response")
+	}
+	return a.BalancesAt(ctx, address, resp.SdkBlock.Header.Time)
+}
+
+// BalancesAt is like Balances but evaluates the vesting schedule at blockTime,
+// which lets callers project when funds unlock. Current balances and
+// delegations are used as-is.
+func (a *AuthClient) BalancesAt(ctx context.Context, address string, blockTime time.Time) (*types.AccountBalance, error) {
+	acc, _, err := a.account(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	total, err := a.bank.AllBalances(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	return splitBalance(address, acc, total, blockTime), nil
+}
+
+func (a *AuthClient) account(ctx context.Context, address string) (sdk.AccountI, string, error) {
+	resp, err := a.query.Account(ctx, &authtypes.QueryAccountRequest{Address: address})
+	if err != nil {
+		return nil, "", queryError("account", err)
+	}
+	acc, err := a.unpack(resp.Account)
+	if err != nil {
+		return nil, "", err
+	}
+	return acc, resp.Account.TypeUrl, nil
+}
+
+func (a *AuthClient) unpack(any *codectypes.Any) (sdk.AccountI, error) {
+	if any == nil {
+		return nil, fmt.Errorf("empty account response")
+	}
+	var acc sdk.AccountI
+	if err := a.registry.UnpackAny(any, &acc); err != nil {
+		return nil, fmt.Errorf("decode account %s: %w", any.TypeUrl, err)
+	}
+	return acc, nil
+}
+
+// splitBalance mirrors the bank module: locked coins come from the vesting
+// schedule net of vesting delegations, and a shortfall in any denom leaves
+// nothing spendable.
+func splitBalance(address string, acc sdk.AccountI, total sdk.Coins, blockTime time.Time) *types.AccountBalance {
+	out := &types.AccountBalance{
+		Address:   address,
+		BlockTime: blockTime,
+		Total:     total,
+		Spendable: total,
+		Locked:    sdk.NewCoins(),
+		Vesting:   sdk.NewCoins(),
+		Vested:    sdk.NewCoins(),
+	}
+	vacc, ok := acc.(vestexported.VestingAccount)
+	if !ok {
+		return out
+	}
+	out.Locked = vacc.LockedCoins(blockTime)
+	out.Vesting = vacc.GetVestingCoins(blockTime)
+	out.Vested = vacc.GetVestedCoins(blockTime)
+	spendable, hasNeg := total.SafeSub(out.Locked...)
+	if hasNeg {
+		spendable = sdk.NewCoins()
+	}
+	out.Spendable = spendable
+	return out
+}
+
+func accountFromSDK(acc sdk.AccountI, typeURL string) *types.Account {
+	// AccAddress.String() depends on the global bech32 prefix; encode explicitly.
+	addr, _ := bech32.ConvertAndEncode(constants.LumeraAccountHRP, acc.GetAddress())
+	out := &types.Account{
+		Address:       addr,
+		AccountNumber: acc.GetAccountNumber(),
+		Sequence:      acc.GetSequence(),
+		PubKey:        acc.GetPubKey(),
+		Type:          types.AccountTypeUnknown,
+		TypeURL:       typeURL,
+	}
+	switch a := acc.(type) {
+	case *authtypes.BaseAccount:
+		out.Type = types.AccountTypeBase
+	case *authtypes.ModuleAccount:
+		out.Type = types.AccountTypeModule
+		out.ModuleName = a.Name
+		out.Permissions = a.Permissions
+	case *vestingtypes.ContinuousVestingAccount:
+		out.Type = types.AccountTypeContinuousVesting
+		out.Vesting = vestingSchedule(a.BaseVestingAccount)
+		out.Vesting.StartTime = time.Unix(a.StartTime, 0).UTC()
+	case *vestingtypes.DelayedVestingAccount:
+		out.Type = types.AccountTypeDelayedVesting
+		out.Vesting = vestingSchedule(a.BaseVestingAccount)
+	case *vestingtypes.PeriodicVestingAccount:
+		out.Type = types.AccountTypePeriodicVesting
+		out.Vesting = vestingSchedule(a.BaseVestingAccount)
+		out.Vesting.StartTime = time.Unix(a.StartTime, 0).UTC()
+		for _, p := range a.VestingPeriods {
+			out.Vesting.Periods = append(out.Vesting.Periods, types.VestingPeriod{
+				Length: time.Duration(p.Length) * time.Second,
+				Amount: p.Amount,
+			})
+		}
+	case *vestingtypes.PermanentLockedAccount:
+		out.Type = types.AccountTypePermanentLocked
+		out.Vesting = vestingSchedule(a.BaseVestingAccount)
+	}
+	return out
+}
+
+func vestingSchedule(b *vestingtypes.BaseVestingAccount) *types.VestingSchedule {
+	if b == nil {
+		return &types.VestingSchedule{}
+	}
+	s := &types.VestingSchedule{
+		OriginalVesting:  b.OriginalVesting,
+		DelegatedFree:    b.DelegatedFree,
+		DelegatedVesting: b.DelegatedVesting,
+	}
+	if b.EndTime > 0 {
+		s.EndTime = time.Unix(b.EndTime, 0).UTC()
+	}
+	return s
+}
@@ -0,0 +1,76 @@
+package blockchain
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	vestingtypes "github.com/cosmos/cosmos-sdk/x/auth/vesting/types"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/LumeraProtocol/sdk-go/types"
+)
+
+type fakeAuthQuery struct {
+	authtypes.QueryClient
+	account *codectypes.Any
+}
+
+func (f *fakeAuthQuery) Account(_ context.Context, _ *authtypes.QueryAccountRequest, _ ...grpc.CallOption) (*authtypes.QueryAccountResponse, error) {
+	if f.account == nil {
+		return nil, status.Error(codes.NotFound, "account not found")
+	}
+	return &authtypes.QueryAccountResponse{Account: f.account}, nil
+}
+
+func TestAuthVestingAccount(t *testing.T) {
+	start := time.Unix(1_700_000_000, 0).UTC()
+	end := start.Add(100 * time.Hour)
+	base := authtypes.NewBaseAccount(make(sdk.AccAddress, 20), nil, 9, 4)
+	vacc, err := vestingtypes.NewContinuousVestingAccount(base, sdk.NewCoins(sdk.NewInt64Coin("ulume", 1000)), start.Unix(), end.Unix())
+	require.NoError(t, err)
+	// 200 of the still-vesting coins are delegated and therefore not locked in the bank balance.
+	vacc.DelegatedVesting = sdk.NewCoins(sdk.NewInt64Coin("ulume", 200))
+	any, err := codectypes.NewAnyWithValue(vacc)
+	require.NoError(t, err)
+
+	bank := &BankClient{query: &fakeBankQuery{balances: sdk.NewCoins(sdk.NewInt64Coin("ulume", 800), sdk.NewInt64Coin("uatom", 5))}}
+	a := newAuthClient(&fakeAuthQuery{account: any}, bank, nil)
+	ctx := context.Background()
+
+	acc, err := a.GetAccount(ctx, "lumera1x")
+	require.NoError(t, err)
+	require.Equal(t, types.AccountTypeContinuousVesting, acc.Type)
+	require.True(t, acc.Type.IsVesting())
+	require.Equal(t, uint64(9), acc.AccountNumber)
+	require.Equal(t, uint64(4), acc.Sequence)
+	require.Contains(t, acc.Address, "lumera1")
+	require.Equal(t, start, acc.Vesting.StartTime)
+	require.Equal(t, end, acc.Vesting.EndTime)
+
+	// Halfway through: 500 vesting, 200 of them delegated -> 300 locked.
+	bal, err := a.BalancesAt(ctx, "lumera1x", start.Add(50*time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, "500ulume", bal.Vesting.String())
+	require.Equal(t, "500ulume", bal.Vested.String())
+	require.Equal(t, "300ulume", bal.Locked.String())
+	require.Equal(t, "5uatom,500ulume", bal.Spendable.String())
+
+	bal, err = a.BalancesAt(ctx, "lumera1x", end)
+	require.NoError(t, err)
+	require.True(t, bal.Locked.IsZero())
+	require.Equal(t, bal.Total, bal.Spendable)
+}
+
+func TestAuthAccountNotFound(t *testing.T) {
+	a := newAuthClient(&fakeAuthQuery{}, nil, nil)
+	_, err := a.GetAccount(context.Background(), "lumera1x")
+	require.True(t, errors.Is(err, types.ErrNotFound))
+}
@@ -0,0 +1,184 @@
+package blockchain
+
+import (
+	"context"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+
+	"github.com/LumeraProtocol/sdk-go/types"
+)
+
+// bankPageSize is used when a bank query is paged through to the end.
+const bankPageSize = 200
+
+// -------- Message Constructors --------
+
+// NewMsgSend constructs a bank MsgSend.
+func NewMsgSend(from, to string, amount sdk.Coins) *banktypes.MsgSend {
+	return &banktypes.MsgSend{
+		FromAddress: from,
+		ToAddress:   to,
+		Amount:      amount,
+	}
+}
+
+// NewMsgMultiSend constructs a bank MsgMultiSend paying outputs from a single
+// sender; the input amount is the sum of the outputs.
+func NewMsgMultiSend(from string, outputs []banktypes.Output) *banktypes.MsgMultiSend {
+	total := sdk.NewCoins()
+	for _, out := range outputs {
+		total = total.Add(out.Coins...)
+	}
+	return &banktypes.MsgMultiSend{
+		Inputs:  []banktypes.Input{{Address: from, Coins: total}},
+		Outputs: outputs,
+	}
+}
+
+// BankClient provides bank module operations
+type BankClient struct {
+	query banktypes.QueryClient
+}
+
+// Balance returns the balance of one denom for an address.
+func (b *BankClient) Balance(ctx context.Context, address, denom string) (sdk.Coin, error) {
+	resp, err := b.query.Balance(ctx, &banktypes.QueryBalanceRequest{Address: address, Denom: denom})
+	if err != nil {
+		return sdk.Coin{}, fmt.Errorf("failed to get balance: %w", err)
+	}
+	if resp.Balance == nil {
+		return sdk.NewInt64Coin(denom, 0), nil
+	}
+	return *resp.Balance, nil
+}
+
+// AllBalances returns every balance held by an address.
+func (b *BankClient) AllBalances(ctx context.Context, address string) (sdk.Coins, error) {
+	var out sdk.Coins
+	var key []byte
+	for {
+		resp, err := b.query.AllBalances(ctx, &banktypes.QueryAllBalancesRequest{
+			Address:    address,
+			Pagination: &query.PageRequest{Key: key, Limit: bankPageSize},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get balances: %w", err)
+		}
+		out = append(out, resp.Balances...)
+		if resp.Pagination == nil || len(resp.Pagination.NextKey) == 0 {
+			return out, nil
+		}
+		key = resp.Pagination.NextKey
+	}
+}
+
+// SpendableBalance returns the spendable (unlocked) balance of one denom.
+func (b *BankClient) SpendableBalance(ctx context.Context, address, denom string) (sdk.Coin, error) {
+	resp, err := b.query.SpendableBalanceByDenom(ctx, &banktypes.QuerySpendableBalanceByDenomRequest{Address: address, Denom: denom})
+	if err != nil {
+		return sdk.Coin{}, fmt.Errorf("failed to get spendable balance: %w", err)
+	}
+	if resp.Balance == nil {
+		return sdk.NewInt64Coin(denom, 0), nil
+	}
+	return *resp.Balance, nil
+}
+
+// SupplyOf returns the total supply of a denom.
+func (b *BankClient) SupplyOf(ctx context.Context, denom string) (sdk.Coin, error) {
+	resp, err := b.query.SupplyOf(ctx, &banktypes.QuerySupplyOfRequest{Denom: denom})
+	if err != nil {
+		return sdk.Coin{}, fmt.Errorf("failed to get supply: %w", err)
+	}
+	return resp.Amount, nil
+}
+
+// TotalSupply returns the total supply of every denom.
+func (b *BankClient) TotalSupply(ctx context.Context) (sdk.Coins, error) {
+	var out sdk.Coins
+	var key []byte
+	for {
+		resp, err := b.query.TotalSupply(ctx, &banktypes.QueryTotalSupplyRequest{
+			Pagination: &query.PageRequest{Key: key, Limit: bankPageSize},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get total supply: %w", err)
+		}
+		out = append(out, resp.Supply...)
+		if resp.Pagination == nil || len(resp.Pagination.NextKey) == 0 {
+			return out, nil
+		}
+		key = resp.Pagination.NextKey
+	}
+}
+
+// DenomMetadata returns the metadata of a denom.
+func (b *BankClient) DenomMetadata(ctx context.Context, denom string) (*banktypes.Metadata, error) {
+	resp, err := b.query.DenomMetadata(ctx, &banktypes.QueryDenomMetadataRequest{Denom: denom})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get denom metadata: %w", err)
+	}
+	return &resp.Metadata, nil
+}
+
+// DenomsMetadata returns the metadata of every registered denom.
+func (b *BankClient) DenomsMetadata(ctx context.Context) ([]banktypes.Metadata, error) {
+	var out []banktypes.Metadata
+	var key []byte
+	for {
+		resp, err := b.query.DenomsMetadata(ctx, &banktypes.QueryDenomsMetadataRequest{
+			Pagination: &query.PageRequest{Key: key, Limit: bankPageSize},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get denoms metadata: %w", err)
+		}
+		out = append(out, resp.Metadatas...)
+		if resp.Pagination == nil || len(resp.Pagination.NextKey) == 0 {
+			return out, nil
+		}
+		key = resp.Pagination.NextKey
+	}
+}
+
+// EnsureBalance returns an error wrapping types.ErrInsufficientFunds when
+// address can spend less than amount. Locked vesting coins count towards
+// Balance but not here, since they cannot pay fees or transfers.
+func (b *BankClient) EnsureBalance(ctx context.Context, address string, amount sdk.Coin) error {
+	bal, err := b.SpendableBalance(ctx, address, amount.Denom)
+	if err != nil {
+		return err
+	}
+	if bal.IsLT(amount) {
+		return fmt.Errorf("%s can spend %s, needs %s: %w", address, bal, amount, types.ErrInsufficientFunds)
+	}
+	return nil
+}
+
+// -------- Transaction Helpers --------
+
+// SendTx builds, signs, broadcasts and confirms a MsgSend.
+func (c *Client) SendTx(ctx context.Context, from, to string, amount sdk.Coins, memo string) (*types.ActionResult, error) {
+	if !amount.IsValid() || amount.IsZero() {
+		return nil, fmt.Errorf("invalid amount %q", amount)
+	}
+	resp, txHash, err := c.sendMsg(ctx, NewMsgSend(from, to, amount), memo)
+	if err != nil {
+		return nil, err
+	}
+	return &types.ActionResult{TxHash: txHash, Height: resp.TxResponse.Height}, nil
+}
+
+// MultiSendTx pays several outputs from one sender in a single transaction.
+func (c *Client) MultiSendTx(ctx context.Context, from string, outputs []banktypes.Output, memo string) (*types.ActionResult, error) {
+	if len(outputs) == 0 {
+		return nil, fmt.Errorf("at least one output is required")
+	}
+	resp, txHash, err := c.sendMsg(ctx, NewMsgMultiSend(from, outputs), memo)
+	if err != nil {
+		return nil, err
+	}
+	return &types.ActionResult{TxHash: txHash, Height: resp.TxResponse.Height}, nil
+}
@@ -0,0 +1,77 @@
+package blockchain
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/LumeraProtocol/sdk-go/types"
+)
+
+type fakeBankQuery struct {
+	banktypes.QueryClient
+	balances sdk.Coins
+	locked   sdk.Coins
+	pages    int
+}
+
+func (f *fakeBankQuery) SpendableBalanceByDenom(_ context.Context, req *banktypes.QuerySpendableBalanceByDenomRequest, _ ...grpc.CallOption) (*banktypes.QuerySpendableBalanceByDenomResponse, error) {
+	c := sdk.NewCoin(req.Denom, f.balances.AmountOf(req.Denom).Sub(f.locked.AmountOf(req.Denom)))
+	return &banktypes.QuerySpendableBalanceByDenomResponse{Balance: &c}, nil
+}
+
+func (f *fakeBankQuery) Balance(_ context.Context, req *banktypes.QueryBalanceRequest, _ ...grpc.CallOption) (*banktypes.QueryBalanceResponse, error) {
+	c := sdk.NewCoin(req.Denom, f.balances.AmountOf(req.Denom))
+	return &banktypes.QueryBalanceResponse{Balance: &c}, nil
+}
+
+func (f *fakeBankQuery) AllBalances(_ context.Context, req *banktypes.QueryAllBalancesRequest, _ ...grpc.CallOption) (*banktypes.QueryAllBalancesResponse, error) {
+	f.pages++
+	i := 0
+	if len(req.Pagination.Key) > 0 {
+		i = int(req.Pagination.Key[0])
+	}
+	resp := &banktypes.QueryAllBalancesResponse{Balances: sdk.Coins{f.balances[i]}, Pagination: &query.PageResponse{}}
+	if i+1 < len(f.balances) {
+		resp.Pagination.NextKey = []byte{byte(i + 1)}
+	}
+	return resp, nil
+}
+
+func TestBankBalances(t *testing.T) {
+	q := &fakeBankQuery{balances: sdk.NewCoins(sdk.NewInt64Coin("uatom", 5), sdk.NewInt64Coin("ulume", 100))}
+	b := &BankClient{query: q}
+	ctx := context.Background()
+
+	all, err := b.AllBalances(ctx, "lumera1x")
+	require.NoError(t, err)
+	require.Equal(t, q.balances, all)
+	require.Equal(t, 2, q.pages)
+
+	require.NoError(t, b.EnsureBalance(ctx, "lumera1x", sdk.NewInt64Coin("ulume", 100)))
+	err = b.EnsureBalance(ctx, "lumera1x", sdk.NewInt64Coin("ulume", 101))
+	require.True(t, errors.Is(err, types.ErrInsufficientFunds))
+	require.ErrorContains(t, err, "can spend 100ulume, needs 101ulume")
+
+	// Locked vesting coins do not count.
+	q.locked = sdk.NewCoins(sdk.NewInt64Coin("ulume", 30))
+	err = b.EnsureBalance(ctx, "lumera1x", sdk.NewInt64Coin("ulume", 71))
+	require.True(t, errors.Is(err, types.ErrInsufficientFunds))
+	require.ErrorContains(t, err, "can spend 70ulume, needs 71ulume")
+}
+
+func TestNewMsgMultiSend(t *testing.T) {
+	msg := NewMsgMultiSend("lumera1from", []banktypes.Output{
+		{Address: "lumera1a", Coins: sdk.NewCoins(sdk.NewInt64Coin("ulume", 10))},
+		{Address: "lumera1b", Coins: sdk.NewCoins(sdk.NewInt64Coin("ulume", 15), sdk.NewInt64Coin("uatom", 1))},
+	})
+	require.Len(t, msg.Inputs, 1)
+	require.Equal(t, "lumera1from", msg.Inputs[0].Address)
+	require.Equal(t, "1uatom,25ulume", msg.Inputs[0].Coins.String())
+}
@@ -1,12 +1,250 @@
 package blockchain
 
 import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	claimcrypto "github.com/LumeraProtocol/lumera/x/claim/keeper/crypto"
 	claimtypes "github.com/LumeraProtocol/lumera/x/claim/types"
+	"github.com/cosmos/btcutil/base58"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/cosmos/cosmos-sdk/types/query"
+
+	"github.com/LumeraProtocol/sdk-go/constants"
+	"github.com/LumeraProtocol/sdk-go/types"
 )
 
+// -------- Message Constructors --------
+
+// NewMsgClaim constructs a MsgClaim.
+func NewMsgClaim(creator, oldAddress, newAddress, pubKeyHex, signatureHex string) *claimtypes.MsgClaim {
+	return &claimtypes.MsgClaim{
+		Creator:    creator,
+		OldAddress: oldAddress,
+		NewAddress: newAddress,
+		PubKey:     pubKeyHex,
+		Signature:  signatureHex,
+	}
+}
+
+// NewMsgDelayedClaim constructs a MsgDelayedClaim for a vesting tier.
+func NewMsgDelayedClaim(creator, oldAddress, newAddress, pubKeyHex, signatureHex string, tier uint32) *claimtypes.MsgDelayedClaim {
+	return &claimtypes.MsgDelayedClaim{
+		Creator:    creator,
+		OldAddress: oldAddress,
+		NewAddress: newAddress,
+		PubKey:     pubKeyHex,
+		Signature:  signatureHex,
+		Tier:       tier,
+	}
+}
+
+// -------- Old-chain key helpers --------
+
+// ClaimPayload returns the message the old-chain key signs: "old.pubkey.new".
+func ClaimPayload(oldAddress, pubKeyHex, newAddress string) string {
+	return oldAddress + "." + pubKeyHex + "." + newAddress
+}
+
+// OldChainAddress derives the old-chain (base58) address of a compressed
+// secp256k1 public key given as hex.
+func OldChainAddress(pubKeyHex string) (string, error) {
+	return claimcrypto.GetAddressFromPubKey(pubKeyHex)
+}
+
+// ParseOldChainPrivKey parses an old-chain private key given as 64 hex
+// characters or in WIF form.
+func ParseOldChainPrivKey(s string) (*secp256k1.PrivKey, error) {
+	s = strings.TrimSpace(s)
+	if bz, err := hex.DecodeString(s); err == nil {
+		if len(bz) != 32 {
+			return nil, fmt.Errorf("private key must be 32 bytes, got %d", len(bz))
+		}
+		return &secp256k1.PrivKey{Key: bz}, nil
+	}
+	payload, _, err := base58.CheckDecode(s)
+	if err != nil {
+		return nil, fmt.Errorf("private key is neither hex nor WIF: %w", err)
+	}
+	switch {
+	case len(payload) == 33 && payload[32] == 0x01:
+		payload = payload[:32]
+	case len(payload) != 32:
+		return nil, fmt.Errorf("unexpected WIF payload length %d", len(payload))
+	}
+	return &secp256k1.PrivKey{Key: payload}, nil
+}
+
+// SignClaim signs the claim payload for newAddress with an old-chain key and
+// returns the derived old address, the compressed public key and the
+// signature, all in the encodings MsgClaim expects.
+func SignClaim(key *secp256k1.PrivKey, newAddress string) (oldAddress, pubKeyHex, signatureHex string, err error) {
+	if key == nil {
+		return "", "", "", fmt.Errorf("old-chain key is required")
+	}
+	if hrp, _, err := bech32.DecodeAndConvert(newAddress); err != nil || hrp != constants.LumeraAccountHRP {
+		return "", "", "", fmt.Errorf("invalid new address %q", newAddress)
+	}
+	pubKeyHex = hex.EncodeToString(key.PubKey().Bytes())
+	oldAddress, err = OldChainAddress(pubKeyHex)
+	if err != nil {
+		return "", "", "", fmt.Errorf("derive old address: %w", err)
+	}
+	signatureHex, err = claimcrypto.SignMessage(key, ClaimPayload(oldAddress, pubKeyHex, newAddress))
+	if err != nil {
+		return "", "", "", err
+	}
+	return oldAddress, pubKeyHex, signatureHex, nil
+}
+
+// VerifyClaimSignature checks a claim signature the same way the chain does.
+func VerifyClaimSignature(oldAddress, newAddress, pubKeyHex, signatureHex string) error {
+	derived, err := OldChainAddress(pubKeyHex)
+	if err != nil {
+		return fmt.Errorf("invalid public key: %w", err)
+	}
+	if derived != oldAddress {
+		return fmt.Errorf("public key belongs to %s, not %s", derived, oldAddress)
+	}
+	ok, err := claimcrypto.VerifySignature(pubKeyHex, ClaimPayload(oldAddress, pubKeyHex, newAddress), signatureHex)
+	if err != nil {
+		return fmt.Errorf("invalid signature: %w", err)
+	}
+	if !ok {
+		return fmt.Errorf("signature does not match claim payload")
+	}
+	return nil
+}
+
 // ClaimClient provides claim module operations
 type ClaimClient struct {
 	query claimtypes.QueryClient
 }
 
-// Add claim-specific methods here as needed
+// GetClaimRecord retrieves the claim record of an old-chain address.
+func (c *ClaimClient) GetClaimRecord(ctx context.Context, oldAddress string) (*types.ClaimRecord, error) {
+	resp, err := c.query.ClaimRecord(ctx, &claimtypes.QueryClaimRecordRequest{Address: oldAddress})
+	if err != nil {
+		return nil, queryError("claim record", err)
+	}
+	if resp.Record == nil {
+		return nil, fmt.Errorf("claim record %s: %w", oldAddress, types.ErrNotFound)
+	}
+	return types.ClaimRecordFromProto(resp.Record), nil
+}
+
+// IsClaimed reports whether the claim record of an old-chain address has been claimed.
+func (c *ClaimClient) IsClaimed(ctx context.Context, oldAddress string) (bool, error) {
+	rec, err := c.GetClaimRecord(ctx, oldAddress)
+	if err != nil {
+		return false, err
+	}
+	return rec.Claimed, nil
+}
+
+// ListClaimed returns a paginated list of claimed records for a vesting tier
+// (0 lists immediate claims).
+func (c *ClaimClient) ListClaimed(ctx context.Context, vestedTier uint32, limit, offset uint64) ([]*types.ClaimRecord, error) {
+	resp, err := c.query.ListClaimed(ctx, &claimtypes.QueryListClaimedRequest{
+		VestedTerm: vestedTier,
+		Pagination: &query.PageRequest{
+			Limit:  limit,
+			Offset: offset,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list claimed: %w", err)
+	}
+	out := make([]*types.ClaimRecord, 0, len(resp.Claims))
+	for _, pb := range resp.Claims {
+		if rec := types.ClaimRecordFromProto(pb); rec != nil {
+			out = append(out, rec)
+		}
+	}
+	return out, nil
+}
+
+// Params retrieves the claim module parameters.
+func (c *ClaimClient) Params(ctx context.Context) (*claimtypes.Params, error) {
+	resp, err := c.query.Params(ctx, &claimtypes.QueryParamsRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get claim params: %w", err)
+	}
+	if resp == nil {
+		return nil, fmt.Errorf("empty params response")
+	}
+	return &resp.Params, nil
+}
+
+// -------- Transaction Helpers --------
+
+// ClaimTx signs the claim payload with the old-chain key, then builds, signs,
+// broadcasts and confirms a MsgClaim moving the balance to newAddress.
+func (c *Client) ClaimTx(ctx context.Context, creator string, oldKey *secp256k1.PrivKey, newAddress, memo string) (*types.ClaimResult, error) {
+	oldAddress, pubKeyHex, sig, err := SignClaim(oldKey, newAddress)
+	if err != nil {
+		return nil, err
+	}
+	return c.ClaimWithSignatureTx(ctx, creator, oldAddress, newAddress, pubKeyHex, sig, memo)
+}
+
+// DelayedClaimTx is ClaimTx for a vesting tier, sending a MsgDelayedClaim.
+func (c *Client) DelayedClaimTx(ctx context.Context, creator string, oldKey *secp256k1.PrivKey, newAddress string, tier uint32, memo string) (*types.ClaimResult, error) {
+	oldAddress, pubKeyHex, sig, err := SignClaim(oldKey, newAddress)
+	if err != nil {
+		return nil, err
+	}
+	return c.DelayedClaimWithSignatureTx(ctx, creator, oldAddress, newAddress, pubKeyHex, sig, tier, memo)
+}
+
+// ClaimWithSignatureTx submits a MsgClaim with a signature produced elsewhere
+// (for example by the old-chain wallet). The signature is verified locally first.
+func (c *Client) ClaimWithSignatureTx(ctx context.Context, creator, oldAddress, newAddress, pubKeyHex, signatureHex, memo string) (*types.ClaimResult, error) {
+	if err := VerifyClaimSignature(oldAddress, newAddress, pubKeyHex, signatureHex); err != nil {
+		return nil, err
+	}
+	msg := NewMsgClaim(creator, oldAddress, newAddress, pubKeyHex, signatureHex)
+	return c.sendClaim(ctx, msg, claimtypes.EventTypeClaimProcessed, oldAddress, newAddress, memo)
+}
+
+// DelayedClaimWithSignatureTx is ClaimWithSignatureTx for a vesting tier.
+func (c *Client) DelayedClaimWithSignatureTx(ctx context.Context, creator, oldAddress, newAddress, pubKeyHex, signatureHex string, tier uint32, memo string) (*types.ClaimResult, error) {
+	if err := VerifyClaimSignature(oldAddress, newAddress, pubKeyHex, signatureHex); err != nil {
+		return nil, err
+	}
+	msg := NewMsgDelayedClaim(creator, oldAddress, newAddress, pubKeyHex, signatureHex, tier)
+	return c.sendClaim(ctx, msg, claimtypes.EventTypeDelayedClaimProcessed, oldAddress, newAddress, memo)
+}
+
+func (c *Client) sendClaim(ctx context.Context, msg sdk.Msg, eventType, oldAddress, newAddress, memo string) (*types.ClaimResult, error) {
+	resp, txHash, err := c.sendMsg(ctx, msg, memo)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &types.ClaimResult{
+		TxHash:     txHash,
+		Height:     resp.TxResponse.Height,
+		OldAddress: oldAddress,
+		NewAddress: newAddress,
+	}
+	amount, err := c.ExtractEventAttribute(resp, eventType, sdk.AttributeKeyAmount)
+	if err != nil {
+		return nil, fmt.Errorf("extract claimed amount: %w", err)
+	}
+	if res.Amount, err = sdk.ParseCoinsNormalized(amount); err != nil {
+		return nil, fmt.Errorf("parse claimed amount %q: %w", amount, err)
+	}
+	if end, err := c.ExtractEventAttribute(resp, eventType, claimtypes.AttributeKeyDelayedEndTime); err == nil {
+		if secs, err := strconv.ParseInt(end, 10, 64); err == nil && secs > 0 {
+			res.DelayedEndTime = time.Unix(secs, 0).UTC()
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,78 @@
+package blockchain
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	claimtypes "github.com/LumeraProtocol/lumera/x/claim/types"
+	"github.com/cosmos/btcutil/base58"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/LumeraProtocol/sdk-go/types"
+)
+
+func TestSignClaim(t *testing.T) {
+	key := secp256k1.GenPrivKey()
+	newAddr, err := bech32.ConvertAndEncode("lumera", key.PubKey().Address())
+	require.NoError(t, err)
+
+	oldAddr, pubKeyHex, sig, err := SignClaim(key, newAddr)
+	require.NoError(t, err)
+	require.Len(t, pubKeyHex, 66)
+	derived, err := OldChainAddress(pubKeyHex)
+	require.NoError(t, err)
+	require.Equal(t, derived, oldAddr)
+
+	require.NoError(t, VerifyClaimSignature(oldAddr, newAddr, pubKeyHex, sig))
+	require.Error(t, VerifyClaimSignature(oldAddr, "lumera1other", pubKeyHex, sig))
+	require.Error(t, VerifyClaimSignature("Ptwrongaddress", newAddr, pubKeyHex, sig))
+
+	_, _, _, err = SignClaim(key, "cosmos1abc")
+	require.Error(t, err)
+	_, _, _, err = SignClaim(nil, newAddr)
+	require.Error(t, err)
+}
+
+func TestParseOldChainPrivKey(t *testing.T) {
+	key := secp256k1.GenPrivKey()
+
+	parsed, err := ParseOldChainPrivKey(hex.EncodeToString(key.Key))
+	require.NoError(t, err)
+	require.Equal(t, key.Key, parsed.Key)
+
+	wif := base58.CheckEncode(append(append([]byte{}, key.Key...), 0x01), 0x80)
+	parsed, err = ParseOldChainPrivKey(wif)
+	require.NoError(t, err)
+	require.Equal(t, key.Key, parsed.Key)
+
+	_, err = ParseOldChainPrivKey("abcd")
+	require.Error(t, err)
+	_, err = ParseOldChainPrivKey("not-a-key")
+	require.Error(t, err)
+}
+
+type fakeClaimQuery struct {
+	claimtypes.QueryClient
+	err error
+}
+
+func (f *fakeClaimQuery) ClaimRecord(context.Context, *claimtypes.QueryClaimRecordRequest, ...grpc.CallOption) (*claimtypes.QueryClaimRecordResponse, error) {
+	return nil, f.err
+}
+
+func TestGetClaimRecordNotFound(t *testing.T) {
+	c := &ClaimClient{query: &fakeClaimQuery{err: status.Error(codes.NotFound, "claim record not found")}}
+	_, err := c.GetClaimRecord(context.Background(), "Ptold")
+	require.True(t, errors.Is(err, types.ErrNotFound))
+
+	c.query = &fakeClaimQuery{err: status.Error(codes.Unavailable, "down")}
+	_, err = c.GetClaimRecord(context.Background(), "Ptold")
+	require.False(t, errors.Is(err, types.ErrNotFound))
+}
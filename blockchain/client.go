@@ -5,12 +5,20 @@ import (
 	"strings"
 
 	sdkmath "cosmossdk.io/math"
+	upgradetypes "cosmossdk.io/x/upgrade/types"
 	"github.com/LumeraProtocol/sdk-go/blockchain/base"
 	"github.com/LumeraProtocol/sdk-go/constants"
+	"github.com/cosmos/cosmos-sdk/client/grpc/cmtservice"
 	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	sdktx "github.com/cosmos/cosmos-sdk/types/tx"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	distrtypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 
 	actiontypes "github.com/LumeraProtocol/lumera/x/action/v1/types"
-	//audittypes "github.com/LumeraProtocol/lumera/x/audit/types"
+	audittypes "github.com/LumeraProtocol/lumera/x/audit/v1/types"
 	claimtypes "github.com/LumeraProtocol/lumera/x/claim/types"
 	supernodetypes "github.com/LumeraProtocol/lumera/x/supernode/v1/types"
 )
@@ -23,10 +31,17 @@ type Client struct {
 	*base.Client
 
 	// Module-specific clients
-	Action    *ActionClient
-	SuperNode *SuperNodeClient
-	Claim     *ClaimClient
-	Audit     *AuditClient
+	Action       *ActionClient
+	SuperNode    *SuperNodeClient
+	Claim        *ClaimClient
+	Audit        *AuditClient
+	Bank         *BankClient
+	Staking      *StakingClient
+	Distribution *DistributionClient
+	Gov          *GovClient
+	Explorer     *ExplorerClient
+	Auth         *AuthClient
+	Upgrade      *UpgradeClient
 }
 
 // New creates a new Lumera blockchain client.
@@ -47,6 +62,9 @@ func New(ctx context.Context, cfg Config, kr keyring.Keyring, keyName string) (*
 	}
 
 	conn := baseClient.GRPCConn()
+	bank := &BankClient{
+		query: banktypes.NewQueryClient(conn),
+	}
 	return &Client{
 		Client: baseClient,
 		Action: &ActionClient{
@@ -59,7 +77,23 @@ func New(ctx context.Context, cfg Config, kr keyring.Keyring, keyName string) (*
 			query: claimtypes.NewQueryClient(conn),
 		},
 		Audit: &AuditClient{
-			//query: audittypes.NewQueryClient(conn),
+			query: audittypes.NewQueryClient(conn),
+		},
+		Bank: bank,
+		Staking: &StakingClient{
+			query: stakingtypes.NewQueryClient(conn),
+		},
+		Distribution: &DistributionClient{
+			query: distrtypes.NewQueryClient(conn),
+		},
+		Gov: &GovClient{
+			query: govv1.NewQueryClient(conn),
+		},
+		Explorer: newExplorerClient(cmtservice.NewServiceClient(conn), sdktx.NewServiceClient(conn), cfg.RPCEndpoint),
+		Auth:     newAuthClient(authtypes.NewQueryClient(conn), bank, cmtservice.NewServiceClient(conn)),
+		Upgrade: &UpgradeClient{
+			query: upgradetypes.NewQueryClient(conn),
+			cmt:   cmtservice.NewServiceClient(conn),
 		},
 	}, nil
 }
@@ -0,0 +1,142 @@
+package blockchain
+
+import (
+	"context"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	distrtypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+
+	"github.com/LumeraProtocol/sdk-go/types"
+)
+
+// -------- Message Constructors --------
+
+// NewMsgWithdrawDelegatorReward constructs a MsgWithdrawDelegatorReward.
+func NewMsgWithdrawDelegatorReward(delegator, validator string) *distrtypes.MsgWithdrawDelegatorReward {
+	return &distrtypes.MsgWithdrawDelegatorReward{
+		DelegatorAddress: delegator,
+		ValidatorAddress: validator,
+	}
+}
+
+// NewMsgWithdrawValidatorCommission constructs a MsgWithdrawValidatorCommission.
+func NewMsgWithdrawValidatorCommission(validator string) *distrtypes.MsgWithdrawValidatorCommission {
+	return &distrtypes.MsgWithdrawValidatorCommission{ValidatorAddress: validator}
+}
+
+// NewMsgSetWithdrawAddress constructs a MsgSetWithdrawAddress.
+func NewMsgSetWithdrawAddress(delegator, withdrawAddress string) *distrtypes.MsgSetWithdrawAddress {
+	return &distrtypes.MsgSetWithdrawAddress{
+		DelegatorAddress: delegator,
+		WithdrawAddress:  withdrawAddress,
+	}
+}
+
+// DistributionClient provides distribution module operations
+type DistributionClient struct {
+	query distrtypes.QueryClient
+}
+
+// Rewards returns the pending rewards of a delegation.
+func (d *DistributionClient) Rewards(ctx context.Context, delegator, validator string) (sdk.DecCoins, error) {
+	resp, err := d.query.DelegationRewards(ctx, &distrtypes.QueryDelegationRewardsRequest{
+		DelegatorAddress: delegator,
+		ValidatorAddress: validator,
+	})
+	if err != nil {
+		return nil, queryError("delegation rewards", err)
+	}
+	return resp.Rewards, nil
+}
+
+// TotalRewards returns the pending rewards of a delegator per validator and in total.
+func (d *DistributionClient) TotalRewards(ctx context.Context, delegator string) ([]distrtypes.DelegationDelegatorReward, sdk.DecCoins, error) {
+	resp, err := d.query.DelegationTotalRewards(ctx, &distrtypes.QueryDelegationTotalRewardsRequest{DelegatorAddress: delegator})
+	if err != nil {
+		return nil, nil, queryError("total rewards", err)
+	}
+	return resp.Rewards, resp.Total, nil
+}
+
+// ValidatorCommission returns the accumulated commission of a validator.
+func (d *DistributionClient) ValidatorCommission(ctx context.Context, validator string) (sdk.DecCoins, error) {
+	resp, err := d.query.ValidatorCommission(ctx, &distrtypes.QueryValidatorCommissionRequest{ValidatorAddress: validator})
+	if err != nil {
+		return nil, queryError("validator commission", err)
+	}
+	return resp.Commission.Commission, nil
+}
+
+// ValidatorOutstandingRewards returns the rewards not yet withdrawn from a validator.
+func (d *DistributionClient) ValidatorOutstandingRewards(ctx context.Context, validator string) (sdk.DecCoins, error) {
+	resp, err := d.query.ValidatorOutstandingRewards(ctx, &distrtypes.QueryValidatorOutstandingRewardsRequest{ValidatorAddress: validator})
+	if err != nil {
+		return nil, queryError("validator outstanding rewards", err)
+	}
+	return resp.Rewards.Rewards, nil
+}
+
+// WithdrawAddress returns the address rewards of delegator are paid to.
+func (d *DistributionClient) WithdrawAddress(ctx context.Context, delegator string) (string, error) {
+	resp, err := d.query.DelegatorWithdrawAddress(ctx, &distrtypes.QueryDelegatorWithdrawAddressRequest{DelegatorAddress: delegator})
+	if err != nil {
+		return "", queryError("withdraw address", err)
+	}
+	return resp.WithdrawAddress, nil
+}
+
+// Params retrieves the distribution module parameters.
+func (d *DistributionClient) Params(ctx context.Context) (*distrtypes.Params, error) {
+	resp, err := d.query.Params(ctx, &distrtypes.QueryParamsRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get distribution params: %w", err)
+	}
+	return &resp.Params, nil
+}
+
+// -------- Transaction Helpers --------
+
+// WithdrawRewardsTx withdraws the rewards of delegator from validator.
+func (c *Client) WithdrawRewardsTx(ctx context.Context, delegator, validator, memo string) (*types.StakingResult, error) {
+	if err := checkValidatorAddresses(validator); err != nil {
+		return nil, err
+	}
+	resp, txHash, err := c.sendMsg(ctx, NewMsgWithdrawDelegatorReward(delegator, validator), memo)
+	if err != nil {
+		return nil, err
+	}
+	res := &types.StakingResult{TxHash: txHash, Height: resp.TxResponse.Height}
+	var out distrtypes.MsgWithdrawDelegatorRewardResponse
+	if err := decodeMsgResponse(resp.TxResponse.Data, "MsgWithdrawDelegatorRewardResponse", &out); err == nil {
+		res.Amount = out.Amount
+	}
+	return res, nil
+}
+
+// WithdrawCommissionTx withdraws a validator's commission. It must be signed
+// by the validator operator account.
+func (c *Client) WithdrawCommissionTx(ctx context.Context, validator, memo string) (*types.StakingResult, error) {
+	if err := checkValidatorAddresses(validator); err != nil {
+		return nil, err
+	}
+	resp, txHash, err := c.sendMsg(ctx, NewMsgWithdrawValidatorCommission(validator), memo)
+	if err != nil {
+		return nil, err
+	}
+	res := &types.StakingResult{TxHash: txHash, Height: resp.TxResponse.Height}
+	var out distrtypes.MsgWithdrawValidatorCommissionResponse
+	if err := decodeMsgResponse(resp.TxResponse.Data, "MsgWithdrawValidatorCommissionResponse", &out); err == nil {
+		res.Amount = out.Amount
+	}
+	return res, nil
+}
+
+// SetWithdrawAddressTx changes where the rewards of delegator are paid.
+func (c *Client) SetWithdrawAddressTx(ctx context.Context, delegator, withdrawAddress, memo string) (*types.StakingResult, error) {
+	resp, txHash, err := c.sendMsg(ctx, NewMsgSetWithdrawAddress(delegator, withdrawAddress), memo)
+	if err != nil {
+		return nil, err
+	}
+	return &types.StakingResult{TxHash: txHash, Height: resp.TxResponse.Height}, nil
+}
@@ -0,0 +1,340 @@
+package blockchain
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	"github.com/cosmos/cosmos-sdk/client/grpc/cmtservice"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdktx "github.com/cosmos/cosmos-sdk/types/tx"
+	gogoproto "github.com/cosmos/gogoproto/proto"
+
+	sdkcrypto "github.com/LumeraProtocol/sdk-go/pkg/crypto"
+	"github.com/LumeraProtocol/sdk-go/types"
+)
+
+const (
+	// defaultTxPageSize is used when a TxPager is created without a page size.
+	defaultTxPageSize = 50
+	// maxTxPageSize is the largest page the tx service accepts.
+	maxTxPageSize = 100
+)
+
+// cometRPC is the subset of the CometBFT RPC client used by the explorer.
+type cometRPC interface {
+	BlockByHash(ctx context.Context, hash []byte) (*coretypes.ResultBlock, error)
+	BlockResults(ctx context.Context, height *int64) (*coretypes.ResultBlockResults, error)
+}
+
+// ExplorerClient reads blocks and transactions and decodes them with the
+// Lumera interface registry.
+type ExplorerClient struct {
+	cmt      cmtservice.ServiceClient
+	txs      sdktx.ServiceClient
+	registry codectypes.InterfaceRegistry
+
+	rpcEndpoint string
+	rpcOnce     sync.Once
+	rpc         cometRPC
+	rpcErr      error
+}
+
+func newExplorerClient(cmt cmtservice.ServiceClient, txs sdktx.ServiceClient, rpcEndpoint string) *ExplorerClient {
+	return &ExplorerClient{
+		cmt:         cmt,
+		txs:         txs,
+		registry:    sdkcrypto.NewInterfaceRegistry(),
+		rpcEndpoint: rpcEndpoint,
+	}
+}
+
+// cometRPC returns the CometBFT RPC client used for queries the gRPC services
+// do not offer.
+func (e *ExplorerClient) cometRPC() (cometRPC, error) {
+	e.rpcOnce.Do(func() {
+		if e.rpc != nil {
+			return
+		}
+		if strings.TrimSpace(e.rpcEndpoint) == "" {
+			e.rpcErr = fmt.Errorf("rpc endpoint is required: %w", types.ErrInvalidConfig)
+			return
+		}
+		e.rpc, e.rpcErr = rpchttp.New(e.rpcEndpoint, "/websocket")
+	})
+	return e.rpc, e.rpcErr
+}
+
+// LatestBlock returns the latest committed block.
+func (e *ExplorerClient) LatestBlock(ctx context.Context) (*types.Block, error) {
+	resp, err := e.cmt.GetLatestBlock(ctx, &cmtservice.GetLatestBlockRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest block: %w", err)
+	}
+	return e.blockFromProto(resp.BlockId.GetHash(), resp.SdkBlock)
+}
+
+// GetBlock returns the block at height.
+func (e *ExplorerClient) GetBlock(ctx context.Context, height int64) (*types.Block, error) {
+	resp, err := e.cmt.GetBlockByHeight(ctx, &cmtservice.GetBlockByHeightRequest{Height: height})
+	if err != nil {
+		return nil, queryError("block", err)
+	}
+	return e.blockFromProto(resp.BlockId.GetHash(), resp.SdkBlock)
+}
+
+// GetBlockByHash returns the block with the given hex hash. It needs the
+// CometBFT RPC endpoint.
+func (e *ExplorerClient) GetBlockByHash(ctx context.Context, hash string) (*types.Block, error) {
+	bz, err := hex.DecodeString(strings.TrimPrefix(strings.ToLower(hash), "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid block hash %q: %w", hash, err)
+	}
+	rpc, err := e.cometRPC()
+	if err != nil {
+		return nil, err
+	}
+	res, err := rpc.BlockByHash(ctx, bz)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block by hash: %w", err)
+	}
+	if res == nil || res.Block == nil {
+		return nil, fmt.Errorf("block %s: %w", hash, types.ErrNotFound)
+	}
+	return e.GetBlock(ctx, res.Block.Height)
+}
+
+// BlockResults returns the execution results and events of the block at
+// height. It needs the CometBFT RPC endpoint.
+func (e *ExplorerClient) BlockResults(ctx context.Context, height int64) (*types.BlockResults, error) {
+	rpc, err := e.cometRPC()
+	if err != nil {
+		return nil, err
+	}
+	res, err := rpc.BlockResults(ctx, &height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block results: %w", err)
+	}
+	out := &types.BlockResults{
+		Height:         res.Height,
+		TxResults:      make([]types.TxResult, 0, len(res.TxsResults)),
+		FinalizeEvents: eventsFromABCI(res.FinalizeBlockEvents),
+	}
+	for _, r := range res.TxsResults {
+		if r == nil {
+			out.TxResults = append(out.TxResults, types.TxResult{})
+			continue
+		}
+		out.TxResults = append(out.TxResults, types.TxResult{
+			Code:      r.Code,
+			Codespace: r.Codespace,
+			Log:       r.Log,
+			GasWanted: r.GasWanted,
+			GasUsed:   r.GasUsed,
+			Events:    eventsFromABCI(r.Events),
+		})
+	}
+	return out, nil
+}
+
+// GetTx returns a decoded transaction by hash.
+func (e *ExplorerClient) GetTx(ctx context.Context, hash string) (*types.Tx, error) {
+	resp, err := e.txs.GetTx(ctx, &sdktx.GetTxRequest{Hash: hash})
+	if err != nil {
+		return nil, queryError("tx", err)
+	}
+	return e.txFromResponse(resp.Tx, resp.TxResponse), nil
+}
+
+// TxsInBlock returns every transaction of the block at height, in block
+// order, with execution results.
+func (e *ExplorerClient) TxsInBlock(ctx context.Context, height int64) ([]*types.Tx, error) {
+	p := e.SearchTxs(fmt.Sprintf("tx.height=%d", height), maxTxPageSize)
+	p.order = sdktx.OrderBy_ORDER_BY_ASC
+	var out []*types.Tx
+	for !p.Done() {
+		page, err := p.Next(ctx)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, page...)
+	}
+	return out, nil
+}
+
+// TxsBySender pages through the transactions signed by address, newest first.
+func (e *ExplorerClient) TxsBySender(address string, pageSize uint64) *TxPager {
+	return e.SearchTxs(fmt.Sprintf("message.sender='%s'", address), pageSize)
+}
+
+// TxsByMessageType pages through the transactions containing a message with
+// the given type URL (e.g. "/lumera.action.v1.MsgRequestAction"), newest first.
+func (e *ExplorerClient) TxsByMessageType(typeURL string, pageSize uint64) *TxPager {
+	return e.SearchTxs(fmt.Sprintf("message.action='%s'", typeURL), pageSize)
+}
+
+// SearchTxs pages through the transactions matching a CometBFT event query,
+// newest first. pageSize is capped at 100; zero uses 50.
+func (e *ExplorerClient) SearchTxs(query string, pageSize uint64) *TxPager {
+	if pageSize == 0 {
+		pageSize = defaultTxPageSize
+	}
+	if pageSize > maxTxPageSize {
+		pageSize = maxTxPageSize
+	}
+	return &TxPager{e: e, query: query, limit: pageSize, order: sdktx.OrderBy_ORDER_BY_DESC}
+}
+
+// TxPager iterates over tx search results one page at a time.
+type TxPager struct {
+	e     *ExplorerClient
+	query string
+	order sdktx.OrderBy
+	limit uint64
+	page  uint64
+	total uint64
+	done  bool
+}
+
+// Done reports whether every page has been returned.
+func (p *TxPager) Done() bool { return p.done }
+
+// Total returns the number of matching transactions reported by the last page.
+func (p *TxPager) Total() uint64 { return p.total }
+
+// Next returns the next page of transactions. It returns nil once Done.
+func (p *TxPager) Next(ctx context.Context) ([]*types.Tx, error) {
+	if p.done {
+		return nil, nil
+	}
+	resp, err := p.e.txs.GetTxsEvent(ctx, &sdktx.GetTxsEventRequest{
+		Query:   p.query,
+		OrderBy: p.order,
+		Page:    p.page + 1,
+		Limit:   p.limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("search txs %q: %w", p.query, err)
+	}
+	p.page++
+	p.total = resp.Total
+	if uint64(len(resp.TxResponses)) < p.limit || p.page*p.limit >= p.total {
+		p.done = true
+	}
+	out := make([]*types.Tx, 0, len(resp.TxResponses))
+	for i, r := range resp.TxResponses {
+		var tx *sdktx.Tx
+		if i < len(resp.Txs) {
+			tx = resp.Txs[i]
+		}
+		out = append(out, p.e.txFromResponse(tx, r))
+	}
+	return out, nil
+}
+
+func (e *ExplorerClient) blockFromProto(hash []byte, b *cmtservice.Block) (*types.Block, error) {
+	if b == nil {
+		return nil, fmt.Errorf("empty block response")
+	}
+	out := &types.Block{
+		Height:   b.Header.Height,
+		Hash:     strings.ToUpper(hex.EncodeToString(hash)),
+		Time:     b.Header.Time,
+		ChainID:  b.Header.ChainID,
+		Proposer: b.Header.ProposerAddress,
+		Txs:      make([]*types.Tx, 0, len(b.Data.Txs)),
+	}
+	for _, raw := range b.Data.Txs {
+		tx := e.txFromRaw(raw)
+		tx.Height = out.Height
+		tx.Time = out.Time
+		out.Txs = append(out.Txs, tx)
+	}
+	return out, nil
+}
+
+// txFromRaw decodes raw tx bytes as found in block data. Undecodable bytes
+// yield a Tx carrying only its hash.
+func (e *ExplorerClient) txFromRaw(raw []byte) *types.Tx {
+	sum := sha256.Sum256(raw)
+	out := &types.Tx{Hash: strings.ToUpper(hex.EncodeToString(sum[:]))}
+	var txRaw sdktx.TxRaw
+	if err := gogoproto.Unmarshal(raw, &txRaw); err != nil {
+		return out
+	}
+	var body sdktx.TxBody
+	var authInfo sdktx.AuthInfo
+	if gogoproto.Unmarshal(txRaw.BodyBytes, &body) != nil || gogoproto.Unmarshal(txRaw.AuthInfoBytes, &authInfo) != nil {
+		return out
+	}
+	e.fillTx(out, &sdktx.Tx{Body: &body, AuthInfo: &authInfo})
+	return out
+}
+
+func (e *ExplorerClient) txFromResponse(tx *sdktx.Tx, r *sdk.TxResponse) *types.Tx {
+	out := &types.Tx{}
+	if r != nil {
+		out.Hash = r.TxHash
+		out.Height = r.Height
+		out.Time, _ = time.Parse(time.RFC3339, r.Timestamp)
+		out.TxResult = types.TxResult{
+			Code:      r.Code,
+			Codespace: r.Codespace,
+			Log:       r.RawLog,
+			GasWanted: r.GasWanted,
+			GasUsed:   r.GasUsed,
+			Events:    eventsFromABCI(r.Events),
+		}
+		if tx == nil && r.Tx != nil {
+			var decoded sdktx.Tx
+			if gogoproto.Unmarshal(r.Tx.Value, &decoded) == nil {
+				tx = &decoded
+			}
+		}
+	}
+	if tx != nil {
+		e.fillTx(out, tx)
+	}
+	return out
+}
+
+func (e *ExplorerClient) fillTx(out *types.Tx, tx *sdktx.Tx) {
+	if tx.Body != nil {
+		out.Memo = tx.Body.Memo
+		out.Messages = make([]types.TxMessage, 0, len(tx.Body.Messages))
+		for _, any := range tx.Body.Messages {
+			if any == nil {
+				continue
+			}
+			m := types.TxMessage{TypeURL: any.TypeUrl}
+			var msg sdk.Msg
+			if err := e.registry.UnpackAny(any, &msg); err == nil {
+				m.Msg = msg
+			}
+			out.Messages = append(out.Messages, m)
+		}
+	}
+	if tx.AuthInfo != nil && tx.AuthInfo.Fee != nil {
+		out.Fee = tx.AuthInfo.Fee.Amount
+	}
+}
+
+func eventsFromABCI(events []abci.Event) []types.Event {
+	out := make([]types.Event, 0, len(events))
+	for _, ev := range events {
+		e := types.Event{Type: ev.Type, Attributes: make([]types.EventAttribute, 0, len(ev.Attributes))}
+		for _, a := range ev.Attributes {
+			e.Attributes = append(e.Attributes, types.EventAttribute{Key: a.Key, Value: a.Value})
+		}
+		out = append(out, e)
+	}
+	return out
+}
@@ -0,0 +1,133 @@
+package blockchain
+
+import (
+	"context"
+	"testing"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	"github.com/cosmos/cosmos-sdk/client/grpc/cmtservice"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdktx "github.com/cosmos/cosmos-sdk/types/tx"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	gogoproto "github.com/cosmos/gogoproto/proto"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+type fakeCmtService struct {
+	cmtservice.ServiceClient
+	block *cmtservice.Block
+}
+
+func (f *fakeCmtService) GetBlockByHeight(_ context.Context, req *cmtservice.GetBlockByHeightRequest, _ ...grpc.CallOption) (*cmtservice.GetBlockByHeightResponse, error) {
+	return &cmtservice.GetBlockByHeightResponse{SdkBlock: f.block}, nil
+}
+
+type fakeTxService struct {
+	sdktx.ServiceClient
+	total   int
+	queries []string
+}
+
+func (f *fakeTxService) GetTxsEvent(_ context.Context, req *sdktx.GetTxsEventRequest, _ ...grpc.CallOption) (*sdktx.GetTxsEventResponse, error) {
+	f.queries = append(f.queries, req.Query)
+	resp := &sdktx.GetTxsEventResponse{Total: uint64(f.total)}
+	for i := int(req.Limit * (req.Page - 1)); i < f.total && len(resp.TxResponses) < int(req.Limit); i++ {
+		resp.TxResponses = append(resp.TxResponses, &sdk.TxResponse{TxHash: string(rune('A' + i)), Height: 10})
+	}
+	return resp, nil
+}
+
+type fakeCometRPC struct {
+	cometRPC
+}
+
+func (fakeCometRPC) BlockResults(_ context.Context, height *int64) (*coretypes.ResultBlockResults, error) {
+	return &coretypes.ResultBlockResults{
+		Height:              *height,
+		TxsResults:          []*abci.ExecTxResult{{Code: 5, Log: "out of gas", Events: []abci.Event{{Type: "tx", Attributes: []abci.EventAttribute{{Key: "fee", Value: "1ulume"}}}}}},
+		FinalizeBlockEvents: []abci.Event{{Type: "mint"}},
+	}, nil
+}
+
+func rawSendTx(t *testing.T) []byte {
+	t.Helper()
+	msg, err := codectypes.NewAnyWithValue(NewMsgSend("lumera1from", "lumera1to", sdk.NewCoins(sdk.NewInt64Coin("ulume", 3))))
+	require.NoError(t, err)
+	unknown := &codectypes.Any{TypeUrl: "/unknown.v1.MsgFoo", Value: []byte{}}
+	body, err := gogoproto.Marshal(&sdktx.TxBody{Messages: []*codectypes.Any{msg, unknown}, Memo: "hi"})
+	require.NoError(t, err)
+	authInfo, err := gogoproto.Marshal(&sdktx.AuthInfo{Fee: &sdktx.Fee{Amount: sdk.NewCoins(sdk.NewInt64Coin("ulume", 7))}})
+	require.NoError(t, err)
+	raw, err := gogoproto.Marshal(&sdktx.TxRaw{BodyBytes: body, AuthInfoBytes: authInfo})
+	require.NoError(t, err)
+	return raw
+}
+
+func TestExplorerGetBlockDecodesTxs(t *testing.T) {
+	block := &cmtservice.Block{Header: cmtservice.Header{Height: 42, ChainID: "lumera-test"}}
+	block.Data.Txs = [][]byte{rawSendTx(t), []byte("garbage")}
+	e := newExplorerClient(&fakeCmtService{block: block}, nil, "")
+
+	b, err := e.GetBlock(context.Background(), 42)
+	require.NoError(t, err)
+	require.Equal(t, int64(42), b.Height)
+	require.Len(t, b.Txs, 2)
+
+	tx := b.Txs[0]
+	require.Len(t, tx.Hash, 64)
+	require.Equal(t, "hi", tx.Memo)
+	require.Equal(t, "7ulume", tx.Fee.String())
+	require.Len(t, tx.Messages, 2)
+	send, ok := tx.Messages[0].Msg.(*banktypes.MsgSend)
+	require.True(t, ok)
+	require.Equal(t, "lumera1to", send.ToAddress)
+	require.Equal(t, "/unknown.v1.MsgFoo", tx.Messages[1].TypeURL)
+	require.Nil(t, tx.Messages[1].Msg)
+
+	require.Empty(t, b.Txs[1].Messages)
+	require.Len(t, b.Txs[1].Hash, 64)
+}
+
+func TestExplorerTxPager(t *testing.T) {
+	svc := &fakeTxService{total: 5}
+	e := newExplorerClient(nil, svc, "")
+
+	p := e.TxsBySender("lumera1x", 2)
+	var hashes []string
+	for !p.Done() {
+		page, err := p.Next(context.Background())
+		require.NoError(t, err)
+		for _, tx := range page {
+			hashes = append(hashes, tx.Hash)
+		}
+	}
+	require.Equal(t, []string{"A", "B", "C", "D", "E"}, hashes)
+	require.Equal(t, uint64(5), p.Total())
+	require.Len(t, svc.queries, 3)
+	require.Equal(t, "message.sender='lumera1x'", svc.queries[0])
+
+	page, err := p.Next(context.Background())
+	require.NoError(t, err)
+	require.Nil(t, page)
+}
+
+func TestExplorerBlockResults(t *testing.T) {
+	e := newExplorerClient(nil, nil, "")
+	_, err := e.BlockResults(context.Background(), 3)
+	require.ErrorContains(t, err, "rpc endpoint is required")
+
+	e = newExplorerClient(nil, nil, "")
+	e.rpc = fakeCometRPC{}
+	res, err := e.BlockResults(context.Background(), 3)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), res.Height)
+	require.Len(t, res.TxResults, 1)
+	require.Equal(t, uint32(5), res.TxResults[0].Code)
+	fee, ok := res.TxResults[0].Events[0].Attribute("fee")
+	require.True(t, ok)
+	require.Equal(t, "1ulume", fee)
+	require.Equal(t, "mint", res.FinalizeEvents[0].Type)
+}
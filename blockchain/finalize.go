@@ -0,0 +1,309 @@
+package blockchain
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	actionkeeper "github.com/LumeraProtocol/lumera/x/action/v1/keeper"
+	actiontypes "github.com/LumeraProtocol/lumera/x/action/v1/types"
+	"github.com/LumeraProtocol/sdk-go/types"
+)
+
+// FinalizeMetadata produces the metadata payload of a MsgFinalizeAction for a
+// specific action type. Implementations validate themselves against the
+// registered action before the message is signed.
+type FinalizeMetadata interface {
+	// ActionType returns the action type the metadata finalizes.
+	ActionType() actiontypes.ActionType
+	// Validate checks the metadata against the registered action.
+	Validate(action *types.Action) error
+	// Marshal renders the JSON metadata string expected by the chain.
+	Marshal() (string, error)
+}
+
+// CascadeFinalizeMetadata finalizes a CASCADE action with the RaptorQ symbol IDs
+// generated by the supernode. The chain keeps the registration signatures.
+type CascadeFinalizeMetadata struct {
+	RQIDs []string
+}
+
+// NewCascadeFinalizeMetadata builds cascade finalize metadata from RQ IDs.
+func NewCascadeFinalizeMetadata(rqIDs ...string) *CascadeFinalizeMetadata {
+	return &CascadeFinalizeMetadata{RQIDs: append([]string(nil), rqIDs...)}
+}
+
+// DeriveCascadeFinalizeMetadata builds cascade finalize metadata with the RQ
+// IDs the chain expects for the registered action.
+func DeriveCascadeFinalizeMetadata(action *types.Action) (*CascadeFinalizeMetadata, error) {
+	if err := validateFinalizable(action, types.ActionTypeCascade); err != nil {
+		return nil, err
+	}
+	meta, ok := action.Metadata.(*types.CascadeMetadata)
+	if !ok {
+		return nil, fmt.Errorf("action %s has no cascade metadata", action.ID)
+	}
+	ids, err := KademliaIDs(meta.Signatures, meta.RQIDsIC, meta.RQIDsMax)
+	if err != nil {
+		return nil, fmt.Errorf("action %s: %w", action.ID, err)
+	}
+	return &CascadeFinalizeMetadata{RQIDs: ids}, nil
+}
+
+// KademliaIDs derives the count IDs the chain accepts for signatures, the
+// i-th being Base58(BLAKE3(zstd(signatures.(ic+i)))). Cascade IDs use the
+// registration signatures and rq_ids_ic; sense IDs use the finalize
+// signatures and dd_and_fingerprints_ic.
+func KademliaIDs(signatures string, ic, count uint64) ([]string, error) {
+	if signatures == "" {
+		return nil, fmt.Errorf("signatures are required to derive ids")
+	}
+	ids := make([]string, count)
+	for i := range ids {
+		id, err := actionkeeper.CreateKademliaID(signatures, ic+uint64(i))
+		if err != nil {
+			return nil, fmt.Errorf("derive id %d: %w", i, err)
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
+
+// ActionType implements FinalizeMetadata.
+func (m *CascadeFinalizeMetadata) ActionType() actiontypes.ActionType {
+	return actiontypes.ActionTypeCascade
+}
+
+// Validate implements FinalizeMetadata. The number of IDs must equal the
+// registered rq_ids_max and every ID must match the one derived from the
+// registration signatures; the chain records evidence against the
+// supernode for a mismatch.
+func (m *CascadeFinalizeMetadata) Validate(action *types.Action) error {
+	if err := validateFinalizable(action, types.ActionTypeCascade); err != nil {
+		return err
+	}
+	meta, ok := action.Metadata.(*types.CascadeMetadata)
+	if !ok {
+		return fmt.Errorf("action %s has no cascade metadata", action.ID)
+	}
+	if meta.RQIDsMax == 0 {
+		return fmt.Errorf("action %s has no rq_ids_max", action.ID)
+	}
+	if err := validateIDs("rq_ids_ids", m.RQIDs, meta.RQIDsMax); err != nil {
+		return err
+	}
+	return matchIDs("rq_ids_ids", m.RQIDs, meta.Signatures, meta.RQIDsIC)
+}
+
+// Marshal implements FinalizeMetadata.
+func (m *CascadeFinalizeMetadata) Marshal() (string, error) {
+	if len(m.RQIDs) == 0 {
+		return "", fmt.Errorf("rq_ids_ids is required")
+	}
+	bz, err := json.Marshal(&actiontypes.CascadeMetadata{RqIdsIds: m.RQIDs})
+	if err != nil {
+		return "", fmt.Errorf("marshal cascade finalize metadata: %w", err)
+	}
+	return string(bz), nil
+}
+
+// SenseFinalizeMetadata finalizes a SENSE action with the duplicate-detection
+// and fingerprint IDs plus the supernode signatures over them.
+type SenseFinalizeMetadata struct {
+	DDAndFingerprintsIDs []string
+	// Signatures has the form "payload.sn1_sig.sn2_sig.sn3_sig"; see SenseFinalizeSignatures.
+	Signatures string
+}
+
+// NewSenseFinalizeMetadata builds sense finalize metadata.
+func NewSenseFinalizeMetadata(ids []string, signatures string) *SenseFinalizeMetadata {
+	return &SenseFinalizeMetadata{
+		DDAndFingerprintsIDs: append([]string(nil), ids...),
+		Signatures:           signatures,
+	}
+}
+
+// DeriveSenseFinalizeMetadata builds sense finalize metadata for signatures
+// with the dd-and-fingerprints IDs the chain expects for the registered action.
+func DeriveSenseFinalizeMetadata(action *types.Action, signatures string) (*SenseFinalizeMetadata, error) {
+	if err := validateFinalizable(action, types.ActionTypeSense); err != nil {
+		return nil, err
+	}
+	meta, ok := action.Metadata.(*types.SenseMetadata)
+	if !ok {
+		return nil, fmt.Errorf("action %s has no sense metadata", action.ID)
+	}
+	if err := validateSenseSignatures(signatures); err != nil {
+		return nil, err
+	}
+	ids, err := KademliaIDs(signatures, meta.DDAndFingerprintsIC, meta.DDAndFingerprintsMax)
+	if err != nil {
+		return nil, fmt.Errorf("action %s: %w", action.ID, err)
+	}
+	return &SenseFinalizeMetadata{DDAndFingerprintsIDs: ids, Signatures: signatures}, nil
+}
+
+// SenseFinalizeSignatures joins the signed payload (Base64 of the dd and
+// fingerprint IDs) and exactly three supernode signatures in the format the
+// chain verifies.
+func SenseFinalizeSignatures(payload string, snSignatures ...string) (string, error) {
+	if payload == "" {
+		return "", fmt.Errorf("payload is required")
+	}
+	if len(snSignatures) != 3 {
+		return "", fmt.Errorf("expected 3 supernode signatures, got %d", len(snSignatures))
+	}
+	parts := append([]string{payload}, snSignatures...)
+	for i, p := range parts {
+		if p == "" || strings.Contains(p, ".") {
+			return "", fmt.Errorf("signature part %d is empty or contains '.'", i)
+		}
+	}
+	return strings.Join(parts, "."), nil
+}
+
+// ActionType implements FinalizeMetadata.
+func (m *SenseFinalizeMetadata) ActionType() actiontypes.ActionType {
+	return actiontypes.ActionTypeSense
+}
+
+// Validate implements FinalizeMetadata. Signatures must be a payload and
+// three Base64 supernode signatures, the number of IDs must equal the
+// registered dd_and_fingerprints_max and every ID must match the one derived
+// from the signatures.
+func (m *SenseFinalizeMetadata) Validate(action *types.Action) error {
+	if err := validateFinalizable(action, types.ActionTypeSense); err != nil {
+		return err
+	}
+	meta, ok := action.Metadata.(*types.SenseMetadata)
+	if !ok {
+		return fmt.Errorf("action %s has no sense metadata", action.ID)
+	}
+	if meta.DDAndFingerprintsMax == 0 {
+		return fmt.Errorf("action %s has no dd_and_fingerprints_max", action.ID)
+	}
+	if err := validateSenseSignatures(m.Signatures); err != nil {
+		return err
+	}
+	if err := validateIDs("dd_and_fingerprints_ids", m.DDAndFingerprintsIDs, meta.DDAndFingerprintsMax); err != nil {
+		return err
+	}
+	return matchIDs("dd_and_fingerprints_ids", m.DDAndFingerprintsIDs, m.Signatures, meta.DDAndFingerprintsIC)
+}
+
+// validateSenseSignatures checks the "payload.sn1_sig.sn2_sig.sn3_sig" form;
+// the chain decodes each supernode signature as Base64.
+func validateSenseSignatures(signatures string) error {
+	parts := strings.Split(signatures, ".")
+	if len(parts) != 4 {
+		return fmt.Errorf("signatures must have 4 '.'-separated parts, got %d", len(parts))
+	}
+	if parts[0] == "" {
+		return fmt.Errorf("signatures: payload is empty")
+	}
+	for i, sig := range parts[1:] {
+		if _, err := base64.StdEncoding.DecodeString(sig); err != nil || sig == "" {
+			return fmt.Errorf("signatures: supernode signature %d is not Base64", i+1)
+		}
+	}
+	return nil
+}
+
+// Marshal implements FinalizeMetadata.
+func (m *SenseFinalizeMetadata) Marshal() (string, error) {
+	if len(m.DDAndFingerprintsIDs) == 0 {
+		return "", fmt.Errorf("dd_and_fingerprints_ids is required")
+	}
+	if m.Signatures == "" {
+		return "", fmt.Errorf("signatures is required")
+	}
+	bz, err := json.Marshal(&actiontypes.SenseMetadata{
+		DdAndFingerprintsIds: m.DDAndFingerprintsIDs,
+		Signatures:           m.Signatures,
+	})
+	if err != nil {
+		return "", fmt.Errorf("marshal sense finalize metadata: %w", err)
+	}
+	return string(bz), nil
+}
+
+func validateFinalizable(action *types.Action, want types.ActionType) error {
+	if action == nil {
+		return fmt.Errorf("action not found")
+	}
+	if action.Type != want {
+		return fmt.Errorf("action %s is %s, not %s", action.ID, action.Type, want)
+	}
+	switch action.State {
+	case types.ActionStatePending, types.ActionStateProcessing:
+		return nil
+	default:
+		return fmt.Errorf("action %s cannot be finalized in state %s", action.ID, action.State)
+	}
+}
+
+func validateIDs(field string, ids []string, max uint64) error {
+	if uint64(len(ids)) != max {
+		return fmt.Errorf("%s: got %d ids, action expects %d", field, len(ids), max)
+	}
+	seen := make(map[string]struct{}, len(ids))
+	for i, id := range ids {
+		if id == "" {
+			return fmt.Errorf("%s: id at position %d is empty", field, i)
+		}
+		if _, dup := seen[id]; dup {
+			return fmt.Errorf("%s: duplicate id %q", field, id)
+		}
+		seen[id] = struct{}{}
+	}
+	return nil
+}
+
+// matchIDs compares ids with the IDs the chain derives from signatures.
+func matchIDs(field string, ids []string, signatures string, ic uint64) error {
+	want, err := KademliaIDs(signatures, ic, uint64(len(ids)))
+	if err != nil {
+		return fmt.Errorf("%s: %w", field, err)
+	}
+	for i, id := range ids {
+		if id != want[i] {
+			return fmt.Errorf("%s: id at position %d is %q, chain expects %q", field, i, id, want[i])
+		}
+	}
+	return nil
+}
+
+// BuildFinalizeActionMessage fetches the registered action, validates meta
+// against it and constructs a MsgFinalizeAction without broadcasting it.
+func (a *ActionClient) BuildFinalizeActionMessage(ctx context.Context, creator, actionID string, meta FinalizeMetadata) (*actiontypes.MsgFinalizeAction, error) {
+	if creator == "" || actionID == "" {
+		return nil, fmt.Errorf("creator and actionID are required")
+	}
+	if meta == nil {
+		return nil, fmt.Errorf("finalize metadata is required")
+	}
+	action, err := a.GetAction(ctx, actionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := meta.Validate(action); err != nil {
+		return nil, fmt.Errorf("invalid finalize metadata: %w", err)
+	}
+	metadata, err := meta.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	return NewMsgFinalizeAction(creator, actionID, meta.ActionType(), metadata), nil
+}
+
+// FinalizeActionWithMetadataTx validates typed finalize metadata against the
+// registered action, then builds, signs, broadcasts and confirms a MsgFinalizeAction.
+func (c *Client) FinalizeActionWithMetadataTx(ctx context.Context, creator, actionID string, meta FinalizeMetadata, memo string) (*types.ActionResult, error) {
+	msg, err := c.Action.BuildFinalizeActionMessage(ctx, creator, actionID, meta)
+	if err != nil {
+		return nil, err
+	}
+	return c.FinalizeActionTx(ctx, creator, actionID, meta.ActionType(), msg.Metadata, memo)
+}
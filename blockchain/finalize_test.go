@@ -0,0 +1,91 @@
+package blockchain
+
+import (
+	"strings"
+	"testing"
+
+	actionkeeper "github.com/LumeraProtocol/lumera/x/action/v1/keeper"
+	actiontypes "github.com/LumeraProtocol/lumera/x/action/v1/types"
+	"github.com/LumeraProtocol/sdk-go/types"
+	"github.com/cosmos/gogoproto/jsonpb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCascadeFinalizeMetadata(t *testing.T) {
+	action := &types.Action{
+		ID:       "1",
+		Type:     types.ActionTypeCascade,
+		State:    types.ActionStatePending,
+		Metadata: &types.CascadeMetadata{RQIDsIC: 5, RQIDsMax: 2, Signatures: "sig"},
+	}
+
+	id5, err := actionkeeper.CreateKademliaID("sig", 5)
+	require.NoError(t, err)
+	id6, err := actionkeeper.CreateKademliaID("sig", 6)
+	require.NoError(t, err)
+
+	meta, err := DeriveCascadeFinalizeMetadata(action)
+	require.NoError(t, err)
+	require.Equal(t, []string{id5, id6}, meta.RQIDs)
+	require.NoError(t, meta.Validate(action))
+	require.NoError(t, NewCascadeFinalizeMetadata(id5, id6).Validate(action))
+
+	out, err := meta.Marshal()
+	require.NoError(t, err)
+	var decoded actiontypes.CascadeMetadata
+	require.NoError(t, (&jsonpb.Unmarshaler{}).Unmarshal(strings.NewReader(out), &decoded))
+	require.Equal(t, []string{id5, id6}, decoded.RqIdsIds)
+
+	// Well-formed but not derived from the registration signatures.
+	err = NewCascadeFinalizeMetadata("id-1", "id-2").Validate(action)
+	require.ErrorContains(t, err, "id at position 0")
+	err = NewCascadeFinalizeMetadata(id6, id5).Validate(action)
+	require.ErrorContains(t, err, "chain expects")
+
+	require.Error(t, NewCascadeFinalizeMetadata("id-1").Validate(action))
+	require.Error(t, NewCascadeFinalizeMetadata("id-1", "id-1").Validate(action))
+	require.Error(t, NewCascadeFinalizeMetadata("id-1", "").Validate(action))
+
+	done := *action
+	done.State = types.ActionStateDone
+	require.Error(t, meta.Validate(&done))
+
+	sense := *action
+	sense.Type = types.ActionTypeSense
+	require.Error(t, meta.Validate(&sense))
+	require.Error(t, meta.Validate(nil))
+}
+
+func TestSenseFinalizeMetadata(t *testing.T) {
+	action := &types.Action{
+		ID:       "2",
+		Type:     types.ActionTypeSense,
+		State:    types.ActionStateProcessing,
+		Metadata: &types.SenseMetadata{DataHash: "h", DDAndFingerprintsIC: 1, DDAndFingerprintsMax: 1},
+	}
+
+	sigs, err := SenseFinalizeSignatures("cGF5bG9hZA==", "czE=", "czI=", "czM=")
+	require.NoError(t, err)
+	require.Equal(t, "cGF5bG9hZA==.czE=.czI=.czM=", sigs)
+	_, err = SenseFinalizeSignatures("p", "s1", "s2")
+	require.Error(t, err)
+
+	id1, err := actionkeeper.CreateKademliaID(sigs, 1)
+	require.NoError(t, err)
+	meta, err := DeriveSenseFinalizeMetadata(action, sigs)
+	require.NoError(t, err)
+	require.Equal(t, []string{id1}, meta.DDAndFingerprintsIDs)
+	require.NoError(t, meta.Validate(action))
+	out, err := meta.Marshal()
+	require.NoError(t, err)
+	var decoded actiontypes.SenseMetadata
+	require.NoError(t, (&jsonpb.Unmarshaler{}).Unmarshal(strings.NewReader(out), &decoded))
+	require.Equal(t, sigs, decoded.Signatures)
+	require.Equal(t, []string{id1}, decoded.DdAndFingerprintsIds)
+
+	require.ErrorContains(t, NewSenseFinalizeMetadata([]string{"dd-1"}, sigs).Validate(action), "chain expects")
+	require.Error(t, NewSenseFinalizeMetadata([]string{id1}, "a.b").Validate(action))
+	require.ErrorContains(t, NewSenseFinalizeMetadata([]string{id1}, "cGF5bG9hZA==.czE=.not base64.czM=").Validate(action), "signature 2")
+	_, err = DeriveSenseFinalizeMetadata(action, "cGF5bG9hZA==..czI=.czM=")
+	require.Error(t, err)
+}
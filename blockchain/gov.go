@@ -0,0 +1,356 @@
+package blockchain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	sdkmath "cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+
+	actiontypes "github.com/LumeraProtocol/lumera/x/action/v1/types"
+	supernodetypes "github.com/LumeraProtocol/lumera/x/supernode/v1/types"
+
+	"github.com/LumeraProtocol/sdk-go/constants"
+	"github.com/LumeraProtocol/sdk-go/types"
+)
+
+// govPageSize is used when a gov query is paged through to the end.
+const govPageSize = 200
+
+// GovAuthority returns the gov module account address, the authority that
+// module MsgUpdateParams messages must name.
+func GovAuthority() string {
+	addr, err := bech32.ConvertAndEncode(constants.LumeraAccountHRP, authtypes.NewModuleAddress(govtypes.ModuleName))
+	if err != nil {
+		panic(err) // a 20-byte address always encodes
+	}
+	return addr
+}
+
+// GovProposal describes a proposal to submit. An empty Deposit uses the
+// chain's minimum (expedited) deposit when submitted through a Propose helper.
+type GovProposal struct {
+	Title     string
+	Summary   string
+	Metadata  string
+	Deposit   sdk.Coins
+	Expedited bool
+}
+
+// -------- Message Constructors --------
+
+// NewMsgSubmitProposal wraps msgs in a gov MsgSubmitProposal.
+func NewMsgSubmitProposal(proposer string, p GovProposal, msgs ...sdk.Msg) (*govv1.MsgSubmitProposal, error) {
+	if len(msgs) == 0 {
+		return nil, fmt.Errorf("proposal needs at least one message")
+	}
+	if p.Title == "" || p.Summary == "" {
+		return nil, fmt.Errorf("proposal title and summary are required")
+	}
+	msg, err := govv1.NewMsgSubmitProposal(msgs, p.Deposit, proposer, p.Metadata, p.Title, p.Summary, p.Expedited)
+	if err != nil {
+		return nil, fmt.Errorf("pack proposal messages: %w", err)
+	}
+	return msg, nil
+}
+
+// NewMsgDeposit constructs a gov MsgDeposit.
+func NewMsgDeposit(depositor string, proposalID uint64, amount sdk.Coins) *govv1.MsgDeposit {
+	return &govv1.MsgDeposit{
+		ProposalId: proposalID,
+		Depositor:  depositor,
+		Amount:     amount,
+	}
+}
+
+// NewMsgVote constructs a gov MsgVote.
+func NewMsgVote(voter string, proposalID uint64, option govv1.VoteOption, metadata string) *govv1.MsgVote {
+	return &govv1.MsgVote{
+		ProposalId: proposalID,
+		Voter:      voter,
+		Option:     option,
+		Metadata:   metadata,
+	}
+}
+
+// NewMsgVoteWeighted constructs a gov MsgVoteWeighted.
+func NewMsgVoteWeighted(voter string, proposalID uint64, options govv1.WeightedVoteOptions, metadata string) *govv1.MsgVoteWeighted {
+	return &govv1.MsgVoteWeighted{
+		ProposalId: proposalID,
+		Voter:      voter,
+		Options:    options,
+		Metadata:   metadata,
+	}
+}
+
+// GovClient provides gov module operations
+type GovClient struct {
+	query govv1.QueryClient
+}
+
+// GetProposal retrieves a proposal by ID.
+func (g *GovClient) GetProposal(ctx context.Context, proposalID uint64) (*govv1.Proposal, error) {
+	resp, err := g.query.Proposal(ctx, &govv1.QueryProposalRequest{ProposalId: proposalID})
+	if err != nil {
+		return nil, queryError("proposal", err)
+	}
+	if resp.Proposal == nil {
+		return nil, fmt.Errorf("proposal %d: %w", proposalID, types.ErrNotFound)
+	}
+	return resp.Proposal, nil
+}
+
+// ListProposals returns a paginated list of proposals. Zero-value status,
+// voter and depositor do not filter.
+func (g *GovClient) ListProposals(ctx context.Context, status govv1.ProposalStatus, voter, depositor string, limit, offset uint64) ([]*govv1.Proposal, error) {
+	resp, err := g.query.Proposals(ctx, &govv1.QueryProposalsRequest{
+		ProposalStatus: status,
+		Voter:          voter,
+		Depositor:      depositor,
+		Pagination:     &query.PageRequest{Limit: limit, Offset: offset},
+	})
+	if err != nil {
+		return nil, queryError("proposals", err)
+	}
+	return resp.Proposals, nil
+}
+
+// GetVote retrieves the vote of voter on a proposal.
+func (g *GovClient) GetVote(ctx context.Context, proposalID uint64, voter string) (*govv1.Vote, error) {
+	resp, err := g.query.Vote(ctx, &govv1.QueryVoteRequest{ProposalId: proposalID, Voter: voter})
+	if err != nil {
+		return nil, queryError("vote", err)
+	}
+	return resp.Vote, nil
+}
+
+// ListVotes returns every vote cast on a proposal.
+func (g *GovClient) ListVotes(ctx context.Context, proposalID uint64) ([]*govv1.Vote, error) {
+	var out []*govv1.Vote
+	var key []byte
+	for {
+		resp, err := g.query.Votes(ctx, &govv1.QueryVotesRequest{
+			ProposalId: proposalID,
+			Pagination: &query.PageRequest{Key: key, Limit: govPageSize},
+		})
+		if err != nil {
+			return nil, queryError("votes", err)
+		}
+		out = append(out, resp.Votes...)
+		if resp.Pagination == nil || len(resp.Pagination.NextKey) == 0 {
+			return out, nil
+		}
+		key = resp.Pagination.NextKey
+	}
+}
+
+// GetDeposit retrieves the deposit of depositor on a proposal.
+func (g *GovClient) GetDeposit(ctx context.Context, proposalID uint64, depositor string) (*govv1.Deposit, error) {
+	resp, err := g.query.Deposit(ctx, &govv1.QueryDepositRequest{ProposalId: proposalID, Depositor: depositor})
+	if err != nil {
+		return nil, queryError("deposit", err)
+	}
+	return resp.Deposit, nil
+}
+
+// ListDeposits returns every deposit made on a proposal.
+func (g *GovClient) ListDeposits(ctx context.Context, proposalID uint64) ([]*govv1.Deposit, error) {
+	var out []*govv1.Deposit
+	var key []byte
+	for {
+		resp, err := g.query.Deposits(ctx, &govv1.QueryDepositsRequest{
+			ProposalId: proposalID,
+			Pagination: &query.PageRequest{Key: key, Limit: govPageSize},
+		})
+		if err != nil {
+			return nil, queryError("deposits", err)
+		}
+		out = append(out, resp.Deposits...)
+		if resp.Pagination == nil || len(resp.Pagination.NextKey) == 0 {
+			return out, nil
+		}
+		key = resp.Pagination.NextKey
+	}
+}
+
+// TallyResult returns the current tally of a proposal.
+func (g *GovClient) TallyResult(ctx context.Context, proposalID uint64) (*govv1.TallyResult, error) {
+	resp, err := g.query.TallyResult(ctx, &govv1.QueryTallyResultRequest{ProposalId: proposalID})
+	if err != nil {
+		return nil, queryError("tally result", err)
+	}
+	return resp.Tally, nil
+}
+
+// Params retrieves the gov module parameters.
+func (g *GovClient) Params(ctx context.Context) (*govv1.Params, error) {
+	resp, err := g.query.Params(ctx, &govv1.QueryParamsRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gov params: %w", err)
+	}
+	if resp.Params == nil {
+		return nil, fmt.Errorf("empty params response")
+	}
+	return resp.Params, nil
+}
+
+// WaitForProposal polls a proposal until it reaches a final status (passed,
+// rejected or failed) or the context is done. A pollInterval <= 0 uses a 5s
+// default. A proposal that is pruned because its deposit period expired
+// returns an error wrapping types.ErrNotFound.
+func (g *GovClient) WaitForProposal(ctx context.Context, proposalID uint64, pollInterval time.Duration) (*govv1.Proposal, error) {
+	if pollInterval <= 0 {
+		pollInterval = 5 * time.Second
+	}
+
+	var lastErr error
+	for {
+		p, err := g.GetProposal(ctx, proposalID)
+		switch {
+		case errors.Is(err, types.ErrNotFound):
+			return nil, fmt.Errorf("proposal %d was removed (deposit period expired?): %w", proposalID, types.ErrNotFound)
+		case err != nil:
+			lastErr = err
+		case proposalFinal(p.Status):
+			return p, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			if lastErr != nil {
+				return nil, fmt.Errorf("wait for proposal %d: %w (last error: %v)", proposalID, ctx.Err(), lastErr)
+			}
+			return nil, fmt.Errorf("wait for proposal %d: %w", proposalID, ctx.Err())
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+func proposalFinal(s govv1.ProposalStatus) bool {
+	switch s {
+	case govv1.StatusPassed, govv1.StatusRejected, govv1.StatusFailed:
+		return true
+	}
+	return false
+}
+
+// -------- Transaction Helpers --------
+
+// SubmitProposalTx wraps msgs in a proposal, submits it and returns the
+// chain-assigned proposal ID.
+func (c *Client) SubmitProposalTx(ctx context.Context, proposer string, p GovProposal, memo string, msgs ...sdk.Msg) (*types.ProposalResult, error) {
+	msg, err := NewMsgSubmitProposal(proposer, p, msgs...)
+	if err != nil {
+		return nil, err
+	}
+	resp, txHash, err := c.sendMsg(ctx, msg, memo)
+	if err != nil {
+		return nil, err
+	}
+	var out govv1.MsgSubmitProposalResponse
+	if err := decodeMsgResponse(resp.TxResponse.Data, "MsgSubmitProposalResponse", &out); err != nil {
+		return nil, fmt.Errorf("extract proposal id: %w", err)
+	}
+	return &types.ProposalResult{TxHash: txHash, Height: resp.TxResponse.Height, ProposalID: out.ProposalId}, nil
+}
+
+// DepositTx adds amount to a proposal's deposit.
+func (c *Client) DepositTx(ctx context.Context, depositor string, proposalID uint64, amount sdk.Coins, memo string) (*types.ActionResult, error) {
+	if !amount.IsValid() || amount.IsZero() {
+		return nil, fmt.Errorf("invalid amount %q", amount)
+	}
+	resp, txHash, err := c.sendMsg(ctx, NewMsgDeposit(depositor, proposalID, amount), memo)
+	if err != nil {
+		return nil, err
+	}
+	return &types.ActionResult{TxHash: txHash, Height: resp.TxResponse.Height}, nil
+}
+
+// VoteTx casts a single-option vote on a proposal.
+func (c *Client) VoteTx(ctx context.Context, voter string, proposalID uint64, option govv1.VoteOption, memo string) (*types.ActionResult, error) {
+	if !govv1.ValidVoteOption(option) {
+		return nil, fmt.Errorf("invalid vote option %s", option)
+	}
+	resp, txHash, err := c.sendMsg(ctx, NewMsgVote(voter, proposalID, option, ""), memo)
+	if err != nil {
+		return nil, err
+	}
+	return &types.ActionResult{TxHash: txHash, Height: resp.TxResponse.Height}, nil
+}
+
+// VoteWeightedTx splits a vote across several options; the weights must sum to 1.
+func (c *Client) VoteWeightedTx(ctx context.Context, voter string, proposalID uint64, options govv1.WeightedVoteOptions, memo string) (*types.ActionResult, error) {
+	if err := checkVoteWeights(options); err != nil {
+		return nil, err
+	}
+	resp, txHash, err := c.sendMsg(ctx, NewMsgVoteWeighted(voter, proposalID, options, ""), memo)
+	if err != nil {
+		return nil, err
+	}
+	return &types.ActionResult{TxHash: txHash, Height: resp.TxResponse.Height}, nil
+}
+
+func checkVoteWeights(options govv1.WeightedVoteOptions) error {
+	if len(options) == 0 {
+		return fmt.Errorf("at least one vote option is required")
+	}
+	total := sdkmath.LegacyZeroDec()
+	for _, o := range options {
+		if o == nil || !govv1.ValidWeightedVoteOption(*o) {
+			return fmt.Errorf("invalid weighted vote option %v", o)
+		}
+		w, _ := sdkmath.LegacyNewDecFromStr(o.Weight)
+		total = total.Add(w)
+	}
+	if !total.Equal(sdkmath.LegacyOneDec()) {
+		return fmt.Errorf("vote weights sum to %s, want 1", total)
+	}
+	return nil
+}
+
+// ProposeActionParams submits a proposal replacing the action module params
+// and blocks until the proposal reaches a final status.
+func (c *Client) ProposeActionParams(ctx context.Context, proposer string, params actiontypes.Params, p GovProposal, memo string, pollInterval time.Duration) (*types.ProposalResult, error) {
+	return c.proposeAndWait(ctx, proposer, p, memo, pollInterval, NewMsgUpdateParams(GovAuthority(), params))
+}
+
+// ProposeSuperNodeParams submits a proposal replacing the supernode module
+// params and blocks until the proposal reaches a final status.
+func (c *Client) ProposeSuperNodeParams(ctx context.Context, proposer string, params supernodetypes.Params, p GovProposal, memo string, pollInterval time.Duration) (*types.ProposalResult, error) {
+	return c.proposeAndWait(ctx, proposer, p, memo, pollInterval, NewSuperNodeMsgUpdateParams(GovAuthority(), params))
+}
+
+// proposeAndWait fills a missing deposit from the gov params, submits the
+// proposal and tracks it. The returned result carries the final status; a
+// tracking error is returned together with the submission result.
+func (c *Client) proposeAndWait(ctx context.Context, proposer string, p GovProposal, memo string, pollInterval time.Duration, msgs ...sdk.Msg) (*types.ProposalResult, error) {
+	if p.Deposit.Empty() {
+		params, err := c.Gov.Params(ctx)
+		if err != nil {
+			return nil, err
+		}
+		p.Deposit = params.MinDeposit
+		if p.Expedited {
+			p.Deposit = params.ExpeditedMinDeposit
+		}
+	}
+
+	res, err := c.SubmitProposalTx(ctx, proposer, p, memo, msgs...)
+	if err != nil {
+		return nil, err
+	}
+	final, err := c.Gov.WaitForProposal(ctx, res.ProposalID, pollInterval)
+	if err != nil {
+		return res, err
+	}
+	res.Status = final.Status.String()
+	res.FailedReason = final.FailedReason
+	return res, nil
+}
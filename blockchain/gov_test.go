@@ -0,0 +1,73 @@
+package blockchain
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	sdkmath "cosmossdk.io/math"
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	actiontypes "github.com/LumeraProtocol/lumera/x/action/v1/types"
+
+	"github.com/LumeraProtocol/sdk-go/types"
+)
+
+type fakeGovQuery struct {
+	govv1.QueryClient
+	statuses []govv1.ProposalStatus
+	calls    int
+}
+
+func (f *fakeGovQuery) Proposal(_ context.Context, req *govv1.QueryProposalRequest, _ ...grpc.CallOption) (*govv1.QueryProposalResponse, error) {
+	if f.calls >= len(f.statuses) {
+		return nil, status.Error(codes.NotFound, "proposal not found")
+	}
+	st := f.statuses[f.calls]
+	f.calls++
+	return &govv1.QueryProposalResponse{Proposal: &govv1.Proposal{Id: req.ProposalId, Status: st}}, nil
+}
+
+func TestWaitForProposal(t *testing.T) {
+	q := &fakeGovQuery{statuses: []govv1.ProposalStatus{govv1.StatusDepositPeriod, govv1.StatusVotingPeriod, govv1.StatusPassed}}
+	g := &GovClient{query: q}
+
+	p, err := g.WaitForProposal(context.Background(), 7, time.Millisecond)
+	require.NoError(t, err)
+	require.Equal(t, uint64(7), p.Id)
+	require.Equal(t, govv1.StatusPassed, p.Status)
+	require.Equal(t, 3, q.calls)
+
+	q = &fakeGovQuery{statuses: []govv1.ProposalStatus{govv1.StatusDepositPeriod}}
+	_, err = (&GovClient{query: q}).WaitForProposal(context.Background(), 7, time.Millisecond)
+	require.True(t, errors.Is(err, types.ErrNotFound))
+}
+
+func TestCheckVoteWeights(t *testing.T) {
+	require.NoError(t, checkVoteWeights(govv1.WeightedVoteOptions{
+		govv1.NewWeightedVoteOption(govv1.OptionYes, sdkmath.LegacyMustNewDecFromStr("0.7")),
+		govv1.NewWeightedVoteOption(govv1.OptionAbstain, sdkmath.LegacyMustNewDecFromStr("0.3")),
+	}))
+	require.ErrorContains(t, checkVoteWeights(govv1.WeightedVoteOptions{
+		govv1.NewWeightedVoteOption(govv1.OptionYes, sdkmath.LegacyMustNewDecFromStr("0.5")),
+	}), "sum to")
+	require.Error(t, checkVoteWeights(nil))
+}
+
+func TestNewMsgSubmitProposal(t *testing.T) {
+	require.True(t, strings.HasPrefix(GovAuthority(), "lumera1"))
+
+	msg, err := NewMsgSubmitProposal("lumera1proposer", GovProposal{Title: "t", Summary: "s"}, NewMsgUpdateParams(GovAuthority(), actiontypes.DefaultParams()))
+	require.NoError(t, err)
+	require.Len(t, msg.Messages, 1)
+	require.True(t, strings.HasSuffix(msg.Messages[0].TypeUrl, "MsgUpdateParams"))
+
+	_, err = NewMsgSubmitProposal("lumera1proposer", GovProposal{Title: "t"}, NewMsgUpdateParams(GovAuthority(), actiontypes.DefaultParams()))
+	require.Error(t, err)
+}
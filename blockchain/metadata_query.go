@@ -0,0 +1,286 @@
+package blockchain
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	actiontypes "github.com/LumeraProtocol/lumera/x/action/v1/types"
+	"github.com/LumeraProtocol/sdk-go/types"
+)
+
+// Metadata fields understood by the chain's QueryActionByMetadata handler.
+const (
+	MetadataFieldDataHash     = "data_hash"
+	MetadataFieldFileName     = "file_name"
+	MetadataFieldPublic       = "public"
+	MetadataFieldCollectionID = "collection_id"
+	MetadataFieldGroupID      = "group_id"
+)
+
+// metadataFields lists the fields accepted per action type. Fields marked as
+// server-side are rendered into the gRPC metadata query; the rest are applied
+// to the decoded results on the client.
+var metadataFields = map[actiontypes.ActionType]map[string]bool{
+	actiontypes.ActionTypeCascade: {
+		MetadataFieldDataHash: true,
+		MetadataFieldFileName: true,
+		MetadataFieldPublic:   false,
+	},
+	actiontypes.ActionTypeSense: {
+		MetadataFieldDataHash:     true,
+		MetadataFieldCollectionID: true,
+		MetadataFieldGroupID:      true,
+	},
+}
+
+type metadataCondition struct {
+	field string
+	value string
+}
+
+// MetadataQueryBuilder builds typed metadata queries for QueryActionByMetadata.
+//
+// The chain matches a single field=value pair per request. The first
+// server-side condition is sent to the chain; any further conditions are
+// applied to the returned page on the client, so a page may contain fewer
+// actions than the requested limit.
+type MetadataQueryBuilder struct {
+	actionType actiontypes.ActionType
+	conds      []metadataCondition
+	err        error
+}
+
+// MetadataQuery starts a new metadata query builder.
+func MetadataQuery() *MetadataQueryBuilder {
+	return &MetadataQueryBuilder{}
+}
+
+// Cascade scopes the query to CASCADE actions.
+func (b *MetadataQueryBuilder) Cascade() *MetadataQueryBuilder {
+	return b.setActionType(actiontypes.ActionTypeCascade)
+}
+
+// Sense scopes the query to SENSE actions.
+func (b *MetadataQueryBuilder) Sense() *MetadataQueryBuilder {
+	return b.setActionType(actiontypes.ActionTypeSense)
+}
+
+// ActionType scopes the query to the action type named by s (case-insensitive).
+// Unknown or unsupported types are recorded as an error returned by Build.
+func (b *MetadataQueryBuilder) ActionType(s string) *MetadataQueryBuilder {
+	at, ok := parseActionType(s)
+	if !ok {
+		if t, tok := parseActionType("ACTION_TYPE_" + s); tok {
+			at, ok = t, true
+		}
+	}
+	if !ok {
+		b.fail(fmt.Errorf("unknown action type %q", s))
+		return b
+	}
+	return b.setActionType(at)
+}
+
+// DataHash matches the metadata data hash (CASCADE and SENSE).
+func (b *MetadataQueryBuilder) DataHash(hash string) *MetadataQueryBuilder {
+	return b.Field(MetadataFieldDataHash, hash)
+}
+
+// FileName matches the metadata file name (CASCADE only).
+func (b *MetadataQueryBuilder) FileName(name string) *MetadataQueryBuilder {
+	return b.Field(MetadataFieldFileName, name)
+}
+
+// Public matches the public flag (CASCADE only, filtered client-side).
+func (b *MetadataQueryBuilder) Public(public bool) *MetadataQueryBuilder {
+	return b.Field(MetadataFieldPublic, strconv.FormatBool(public))
+}
+
+// CollectionID matches the collection ID (SENSE only).
+func (b *MetadataQueryBuilder) CollectionID(id string) *MetadataQueryBuilder {
+	return b.Field(MetadataFieldCollectionID, id)
+}
+
+// GroupID matches the group ID (SENSE only).
+func (b *MetadataQueryBuilder) GroupID(id string) *MetadataQueryBuilder {
+	return b.Field(MetadataFieldGroupID, id)
+}
+
+// Field adds a raw field condition. The field is validated against the action
+// type when the query is built.
+func (b *MetadataQueryBuilder) Field(field, value string) *MetadataQueryBuilder {
+	field = strings.TrimSpace(field)
+	if field == "" {
+		b.fail(fmt.Errorf("metadata field is required"))
+		return b
+	}
+	if value == "" {
+		b.fail(fmt.Errorf("metadata field %q requires a value", field))
+		return b
+	}
+	for _, c := range b.conds {
+		if c.field == field {
+			b.fail(fmt.Errorf("metadata field %q set more than once", field))
+			return b
+		}
+	}
+	b.conds = append(b.conds, metadataCondition{field: field, value: value})
+	return b
+}
+
+// Build validates the query and returns the action type and rendered
+// field=value string sent to the chain.
+func (b *MetadataQueryBuilder) Build() (actiontypes.ActionType, string, error) {
+	if err := b.validate(); err != nil {
+		return 0, "", err
+	}
+	server, _ := b.split()
+	return b.actionType, server.field + "=" + server.value, nil
+}
+
+// String renders the server-side query, or an empty string when invalid.
+func (b *MetadataQueryBuilder) String() string {
+	_, q, err := b.Build()
+	if err != nil {
+		return ""
+	}
+	return q
+}
+
+func (b *MetadataQueryBuilder) setActionType(at actiontypes.ActionType) *MetadataQueryBuilder {
+	if _, ok := metadataFields[at]; !ok {
+		b.fail(fmt.Errorf("action type %s does not support metadata queries", at))
+		return b
+	}
+	if b.actionType != actiontypes.ActionTypeUnspecified && b.actionType != at {
+		b.fail(fmt.Errorf("action type already set to %s", b.actionType))
+		return b
+	}
+	b.actionType = at
+	return b
+}
+
+func (b *MetadataQueryBuilder) fail(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
+
+func (b *MetadataQueryBuilder) validate() error {
+	if b == nil {
+		return fmt.Errorf("metadata query is required")
+	}
+	if b.err != nil {
+		return b.err
+	}
+	fields, ok := metadataFields[b.actionType]
+	if !ok {
+		return fmt.Errorf("action type is required (use Cascade, Sense or ActionType)")
+	}
+	if len(b.conds) == 0 {
+		return fmt.Errorf("at least one metadata field is required")
+	}
+	hasServer := false
+	for _, c := range b.conds {
+		server, ok := fields[c.field]
+		if !ok {
+			return fmt.Errorf("metadata field %q is not supported for %s actions", c.field, b.actionType)
+		}
+		if c.field == MetadataFieldPublic {
+			if _, err := strconv.ParseBool(c.value); err != nil {
+				return fmt.Errorf("metadata field %q: %w", c.field, err)
+			}
+		}
+		hasServer = hasServer || server
+	}
+	if !hasServer {
+		return fmt.Errorf("query needs at least one chain-indexed field for %s actions", b.actionType)
+	}
+	return nil
+}
+
+// split returns the condition sent to the chain and the ones filtered locally.
+func (b *MetadataQueryBuilder) split() (metadataCondition, []metadataCondition) {
+	fields := metadataFields[b.actionType]
+	var server metadataCondition
+	var local []metadataCondition
+	found := false
+	for _, c := range b.conds {
+		if !found && fields[c.field] {
+			server = c
+			found = true
+			continue
+		}
+		local = append(local, c)
+	}
+	return server, local
+}
+
+// matches reports whether the decoded action satisfies every condition.
+func (b *MetadataQueryBuilder) matches(action *types.Action, conds []metadataCondition) bool {
+	if len(conds) == 0 {
+		return true
+	}
+	if action == nil || action.Metadata == nil {
+		return false
+	}
+	for _, c := range conds {
+		var got string
+		switch m := action.Metadata.(type) {
+		case *types.CascadeMetadata:
+			switch c.field {
+			case MetadataFieldDataHash:
+				got = m.DataHash
+			case MetadataFieldFileName:
+				got = m.FileName
+			case MetadataFieldPublic:
+				want, _ := strconv.ParseBool(c.value)
+				if m.Public != want {
+					return false
+				}
+				continue
+			}
+		case *types.SenseMetadata:
+			switch c.field {
+			case MetadataFieldDataHash:
+				got = m.DataHash
+			case MetadataFieldCollectionID:
+				got = m.CollectionID
+			case MetadataFieldGroupID:
+				got = m.GroupID
+			}
+		default:
+			return false
+		}
+		if got != c.value {
+			return false
+		}
+	}
+	return true
+}
+
+// QueryActionsByMetadata queries actions using a typed metadata query with pagination.
+// Conditions beyond the first chain-indexed field are applied to the returned page.
+func (a *ActionClient) QueryActionsByMetadata(ctx context.Context, q *MetadataQueryBuilder, limit, offset uint64) ([]*types.Action, error) {
+	if err := q.validate(); err != nil {
+		return nil, fmt.Errorf("invalid metadata query: %w", err)
+	}
+	server, local := q.split()
+	actions, err := a.QueryActionByMetadataEnum(ctx, q.actionType, server.field+"="+server.value, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	if len(local) == 0 {
+		return actions, nil
+	}
+
+	filtered := actions[:0]
+	for _, action := range actions {
+		if q.matches(action, local) {
+			filtered = append(filtered, action)
+		}
+	}
+	return filtered, nil
+}
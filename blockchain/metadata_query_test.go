@@ -0,0 +1,68 @@
+package blockchain
+
+import (
+	"testing"
+
+	actiontypes "github.com/LumeraProtocol/lumera/x/action/v1/types"
+	"github.com/LumeraProtocol/sdk-go/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetadataQueryBuild(t *testing.T) {
+	at, q, err := MetadataQuery().Cascade().DataHash("abc").FileName("f.txt").Public(true).Build()
+	require.NoError(t, err)
+	require.Equal(t, actiontypes.ActionTypeCascade, at)
+	require.Equal(t, "data_hash=abc", q)
+
+	at, q, err = MetadataQuery().ActionType("sense").Public(false).Build()
+	require.Error(t, err)
+	require.Zero(t, at)
+	require.Empty(t, q)
+
+	at, q, err = MetadataQuery().ActionType("ACTION_TYPE_SENSE").CollectionID("c1").Build()
+	require.NoError(t, err)
+	require.Equal(t, actiontypes.ActionTypeSense, at)
+	require.Equal(t, "collection_id=c1", q)
+
+	_, q, err = MetadataQuery().Cascade().Public(true).FileName("a=b").Build()
+	require.NoError(t, err)
+	require.Equal(t, "file_name=a=b", q)
+}
+
+func TestMetadataQueryRejectsInvalid(t *testing.T) {
+	cases := map[string]*MetadataQueryBuilder{
+		"no action type":      MetadataQuery().DataHash("abc"),
+		"unknown action type": MetadataQuery().ActionType("bogus").DataHash("abc"),
+		"unspecified type":    MetadataQuery().ActionType("unspecified").DataHash("abc"),
+		"conflicting types":   MetadataQuery().Cascade().Sense().DataHash("abc"),
+		"no fields":           MetadataQuery().Cascade(),
+		"field for sense":     MetadataQuery().Sense().FileName("f.txt"),
+		"field for cascade":   MetadataQuery().Cascade().GroupID("g"),
+		"unknown field":       MetadataQuery().Cascade().Field("owner", "x"),
+		"empty value":         MetadataQuery().Cascade().DataHash(""),
+		"duplicate field":     MetadataQuery().Cascade().DataHash("a").DataHash("b"),
+		"bad public value":    MetadataQuery().Cascade().DataHash("a").Field("public", "yes"),
+	}
+	for name, b := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, _, err := b.Build()
+			require.Error(t, err)
+			require.Empty(t, b.String())
+		})
+	}
+}
+
+func TestMetadataQueryMatchesLocalConditions(t *testing.T) {
+	b := MetadataQuery().Cascade().DataHash("abc").FileName("f.txt").Public(true)
+	_, local := b.split()
+	require.Len(t, local, 2)
+
+	match := &types.Action{Metadata: &types.CascadeMetadata{DataHash: "abc", FileName: "f.txt", Public: true}}
+	private := &types.Action{Metadata: &types.CascadeMetadata{DataHash: "abc", FileName: "f.txt"}}
+	renamed := &types.Action{Metadata: &types.CascadeMetadata{DataHash: "abc", FileName: "g.txt", Public: true}}
+
+	require.True(t, b.matches(match, local))
+	require.False(t, b.matches(private, local))
+	require.False(t, b.matches(renamed, local))
+	require.False(t, b.matches(&types.Action{}, local))
+}
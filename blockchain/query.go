@@ -1,10 +1,15 @@
 package blockchain
 
 import (
+	"fmt"
 	"strings"
 
 	actiontypes "github.com/LumeraProtocol/lumera/x/action/v1/types"
 	"github.com/cosmos/cosmos-sdk/types/query"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/LumeraProtocol/sdk-go/types"
 )
 
 // QueryOption is a functional option for queries
@@ -101,4 +106,13 @@ func parseActionState(s string) (actiontypes.ActionState, bool) {
 		return actiontypes.ActionState(v), true
 	}
 	return 0, false
-}
\ No newline at end of file
+}
+
+// queryError wraps a query failure, adding types.ErrNotFound when the chain
+// reports a missing record.
+func queryError(what string, err error) error {
+	if status.Code(err) == codes.NotFound {
+		return fmt.Errorf("failed to get %s: %w: %w", what, types.ErrNotFound, err)
+	}
+	return fmt.Errorf("failed to get %s: %w", what, err)
+}
@@ -0,0 +1,239 @@
+package blockchain
+
+import (
+	"context"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+
+	"github.com/LumeraProtocol/sdk-go/constants"
+	"github.com/LumeraProtocol/sdk-go/types"
+)
+
+// stakingPageSize is used when a staking query is paged through to the end.
+const stakingPageSize = 200
+
+// -------- Message Constructors --------
+
+// NewMsgDelegate constructs a MsgDelegate.
+func NewMsgDelegate(delegator, validator string, amount sdk.Coin) *stakingtypes.MsgDelegate {
+	return &stakingtypes.MsgDelegate{
+		DelegatorAddress: delegator,
+		ValidatorAddress: validator,
+		Amount:           amount,
+	}
+}
+
+// NewMsgUndelegate constructs a MsgUndelegate.
+func NewMsgUndelegate(delegator, validator string, amount sdk.Coin) *stakingtypes.MsgUndelegate {
+	return &stakingtypes.MsgUndelegate{
+		DelegatorAddress: delegator,
+		ValidatorAddress: validator,
+		Amount:           amount,
+	}
+}
+
+// NewMsgBeginRedelegate constructs a MsgBeginRedelegate.
+func NewMsgBeginRedelegate(delegator, srcValidator, dstValidator string, amount sdk.Coin) *stakingtypes.MsgBeginRedelegate {
+	return &stakingtypes.MsgBeginRedelegate{
+		DelegatorAddress:    delegator,
+		ValidatorSrcAddress: srcValidator,
+		ValidatorDstAddress: dstValidator,
+		Amount:              amount,
+	}
+}
+
+// StakingClient provides staking module operations
+type StakingClient struct {
+	query stakingtypes.QueryClient
+}
+
+// GetValidator retrieves a validator by operator address.
+func (s *StakingClient) GetValidator(ctx context.Context, validatorAddr string) (*stakingtypes.Validator, error) {
+	resp, err := s.query.Validator(ctx, &stakingtypes.QueryValidatorRequest{ValidatorAddr: validatorAddr})
+	if err != nil {
+		return nil, queryError("validator", err)
+	}
+	return &resp.Validator, nil
+}
+
+// ListValidators returns a paginated list of validators. status filters by
+// bond status ("BOND_STATUS_BONDED", ...); empty lists all.
+func (s *StakingClient) ListValidators(ctx context.Context, status string, limit, offset uint64) ([]stakingtypes.Validator, error) {
+	resp, err := s.query.Validators(ctx, &stakingtypes.QueryValidatorsRequest{
+		Status:     status,
+		Pagination: &query.PageRequest{Limit: limit, Offset: offset},
+	})
+	if err != nil {
+		return nil, queryError("validators", err)
+	}
+	return resp.Validators, nil
+}
+
+// GetDelegation retrieves the delegation of delegator to validator.
+func (s *StakingClient) GetDelegation(ctx context.Context, delegator, validator string) (*stakingtypes.DelegationResponse, error) {
+	resp, err := s.query.Delegation(ctx, &stakingtypes.QueryDelegationRequest{
+		DelegatorAddr: delegator,
+		ValidatorAddr: validator,
+	})
+	if err != nil {
+		return nil, queryError("delegation", err)
+	}
+	if resp.DelegationResponse == nil {
+		return nil, fmt.Errorf("delegation %s/%s: %w", delegator, validator, types.ErrNotFound)
+	}
+	return resp.DelegationResponse, nil
+}
+
+// ListDelegations returns every delegation of a delegator.
+func (s *StakingClient) ListDelegations(ctx context.Context, delegator string) ([]stakingtypes.DelegationResponse, error) {
+	var out []stakingtypes.DelegationResponse
+	var key []byte
+	for {
+		resp, err := s.query.DelegatorDelegations(ctx, &stakingtypes.QueryDelegatorDelegationsRequest{
+			DelegatorAddr: delegator,
+			Pagination:    &query.PageRequest{Key: key, Limit: stakingPageSize},
+		})
+		if err != nil {
+			return nil, queryError("delegations", err)
+		}
+		out = append(out, resp.DelegationResponses...)
+		if resp.Pagination == nil || len(resp.Pagination.NextKey) == 0 {
+			return out, nil
+		}
+		key = resp.Pagination.NextKey
+	}
+}
+
+// ListValidatorDelegations returns a paginated list of delegations to a validator.
+func (s *StakingClient) ListValidatorDelegations(ctx context.Context, validator string, limit, offset uint64) ([]stakingtypes.DelegationResponse, error) {
+	resp, err := s.query.ValidatorDelegations(ctx, &stakingtypes.QueryValidatorDelegationsRequest{
+		ValidatorAddr: validator,
+		Pagination:    &query.PageRequest{Limit: limit, Offset: offset},
+	})
+	if err != nil {
+		return nil, queryError("validator delegations", err)
+	}
+	return resp.DelegationResponses, nil
+}
+
+// ListUnbondingDelegations returns every unbonding delegation of a delegator.
+func (s *StakingClient) ListUnbondingDelegations(ctx context.Context, delegator string) ([]stakingtypes.UnbondingDelegation, error) {
+	var out []stakingtypes.UnbondingDelegation
+	var key []byte
+	for {
+		resp, err := s.query.DelegatorUnbondingDelegations(ctx, &stakingtypes.QueryDelegatorUnbondingDelegationsRequest{
+			DelegatorAddr: delegator,
+			Pagination:    &query.PageRequest{Key: key, Limit: stakingPageSize},
+		})
+		if err != nil {
+			return nil, queryError("unbonding delegations", err)
+		}
+		out = append(out, resp.UnbondingResponses...)
+		if resp.Pagination == nil || len(resp.Pagination.NextKey) == 0 {
+			return out, nil
+		}
+		key = resp.Pagination.NextKey
+	}
+}
+
+// ListRedelegations returns every redelegation of a delegator.
+func (s *StakingClient) ListRedelegations(ctx context.Context, delegator string) ([]stakingtypes.RedelegationResponse, error) {
+	var out []stakingtypes.RedelegationResponse
+	var key []byte
+	for {
+		resp, err := s.query.Redelegations(ctx, &stakingtypes.QueryRedelegationsRequest{
+			DelegatorAddr: delegator,
+			Pagination:    &query.PageRequest{Key: key, Limit: stakingPageSize},
+		})
+		if err != nil {
+			return nil, queryError("redelegations", err)
+		}
+		out = append(out, resp.RedelegationResponses...)
+		if resp.Pagination == nil || len(resp.Pagination.NextKey) == 0 {
+			return out, nil
+		}
+		key = resp.Pagination.NextKey
+	}
+}
+
+// Params retrieves the staking module parameters.
+func (s *StakingClient) Params(ctx context.Context) (*stakingtypes.Params, error) {
+	resp, err := s.query.Params(ctx, &stakingtypes.QueryParamsRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get staking params: %w", err)
+	}
+	return &resp.Params, nil
+}
+
+// -------- Transaction Helpers --------
+
+// DelegateTx delegates amount from delegator to validator.
+func (c *Client) DelegateTx(ctx context.Context, delegator, validator string, amount sdk.Coin, memo string) (*types.StakingResult, error) {
+	if err := checkStakeArgs(amount, validator); err != nil {
+		return nil, err
+	}
+	resp, txHash, err := c.sendMsg(ctx, NewMsgDelegate(delegator, validator, amount), memo)
+	if err != nil {
+		return nil, err
+	}
+	return &types.StakingResult{TxHash: txHash, Height: resp.TxResponse.Height}, nil
+}
+
+// UndelegateTx starts unbonding amount from validator; the result carries the
+// completion time reported by the chain.
+func (c *Client) UndelegateTx(ctx context.Context, delegator, validator string, amount sdk.Coin, memo string) (*types.StakingResult, error) {
+	if err := checkStakeArgs(amount, validator); err != nil {
+		return nil, err
+	}
+	resp, txHash, err := c.sendMsg(ctx, NewMsgUndelegate(delegator, validator, amount), memo)
+	if err != nil {
+		return nil, err
+	}
+	res := &types.StakingResult{TxHash: txHash, Height: resp.TxResponse.Height}
+	var out stakingtypes.MsgUndelegateResponse
+	if err := decodeMsgResponse(resp.TxResponse.Data, "MsgUndelegateResponse", &out); err == nil {
+		res.CompletionTime = out.CompletionTime
+		res.Amount = sdk.NewCoins(out.Amount)
+	}
+	return res, nil
+}
+
+// RedelegateTx moves amount from srcValidator to dstValidator without unbonding.
+func (c *Client) RedelegateTx(ctx context.Context, delegator, srcValidator, dstValidator string, amount sdk.Coin, memo string) (*types.StakingResult, error) {
+	if err := checkStakeArgs(amount, srcValidator, dstValidator); err != nil {
+		return nil, err
+	}
+	if srcValidator == dstValidator {
+		return nil, fmt.Errorf("source and destination validators must differ")
+	}
+	resp, txHash, err := c.sendMsg(ctx, NewMsgBeginRedelegate(delegator, srcValidator, dstValidator, amount), memo)
+	if err != nil {
+		return nil, err
+	}
+	res := &types.StakingResult{TxHash: txHash, Height: resp.TxResponse.Height}
+	var out stakingtypes.MsgBeginRedelegateResponse
+	if err := decodeMsgResponse(resp.TxResponse.Data, "MsgBeginRedelegateResponse", &out); err == nil {
+		res.CompletionTime = out.CompletionTime
+	}
+	return res, nil
+}
+
+func checkStakeArgs(amount sdk.Coin, validators ...string) error {
+	if !amount.IsValid() || !amount.IsPositive() {
+		return fmt.Errorf("invalid amount %q", amount)
+	}
+	return checkValidatorAddresses(validators...)
+}
+
+func checkValidatorAddresses(validators ...string) error {
+	for _, v := range validators {
+		if hrp, _, err := bech32.DecodeAndConvert(v); err != nil || hrp != constants.LumeraValidatorHRP {
+			return fmt.Errorf("invalid validator address %q", v)
+		}
+	}
+	return nil
+}
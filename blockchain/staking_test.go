@@ -0,0 +1,58 @@
+package blockchain
+
+import (
+	"context"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+type fakeStakingQuery struct {
+	stakingtypes.QueryClient
+	delegations []stakingtypes.DelegationResponse
+}
+
+func (f *fakeStakingQuery) DelegatorDelegations(_ context.Context, req *stakingtypes.QueryDelegatorDelegationsRequest, _ ...grpc.CallOption) (*stakingtypes.QueryDelegatorDelegationsResponse, error) {
+	i := 0
+	if len(req.Pagination.Key) > 0 {
+		i = int(req.Pagination.Key[0])
+	}
+	resp := &stakingtypes.QueryDelegatorDelegationsResponse{
+		DelegationResponses: f.delegations[i : i+1],
+		Pagination:          &query.PageResponse{},
+	}
+	if i+1 < len(f.delegations) {
+		resp.Pagination.NextKey = []byte{byte(i + 1)}
+	}
+	return resp, nil
+}
+
+func TestListDelegationsPages(t *testing.T) {
+	q := &fakeStakingQuery{delegations: []stakingtypes.DelegationResponse{
+		{Balance: sdk.NewInt64Coin("ulume", 1)},
+		{Balance: sdk.NewInt64Coin("ulume", 2)},
+		{Balance: sdk.NewInt64Coin("ulume", 3)},
+	}}
+	s := &StakingClient{query: q}
+
+	out, err := s.ListDelegations(context.Background(), "lumera1x")
+	require.NoError(t, err)
+	require.Equal(t, q.delegations, out)
+}
+
+func TestCheckStakeArgs(t *testing.T) {
+	valoper, err := bech32.ConvertAndEncode("lumeravaloper", make([]byte, 20))
+	require.NoError(t, err)
+	account, err := bech32.ConvertAndEncode("lumera", make([]byte, 20))
+	require.NoError(t, err)
+
+	require.NoError(t, checkStakeArgs(sdk.NewInt64Coin("ulume", 1), valoper))
+	require.ErrorContains(t, checkStakeArgs(sdk.NewInt64Coin("ulume", 0), valoper), "invalid amount")
+	require.ErrorContains(t, checkStakeArgs(sdk.NewInt64Coin("ulume", 1), valoper, account), "invalid validator address")
+	require.ErrorContains(t, checkValidatorAddresses("garbage"), "invalid validator address")
+}
@@ -92,12 +92,29 @@ func (s *SuperNodeClient) GetSuperNode(ctx context.Context, validatorAddr string
 		ValidatorAddress: validatorAddr,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get supernode: %w", err)
+		return nil, queryError("supernode", err)
 	}
 
+	if resp.Supernode == nil {
+		return nil, fmt.Errorf("supernode %s: %w", validatorAddr, types.ErrNotFound)
+	}
 	return types.SuperNodeFromProto(resp.Supernode), nil
 }
 
+// GetMetrics retrieves the latest metrics report submitted by a supernode.
+func (s *SuperNodeClient) GetMetrics(ctx context.Context, validatorAddr string) (*types.SuperNodeMetrics, error) {
+	resp, err := s.query.GetMetrics(ctx, &supernodetypes.QueryGetMetricsRequest{
+		ValidatorAddress: validatorAddr,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get supernode metrics: %w", err)
+	}
+	if resp.MetricsState == nil {
+		return nil, fmt.Errorf("supernode %s metrics: %w", validatorAddr, types.ErrNotFound)
+	}
+	return types.SuperNodeMetricsFromProto(resp.MetricsState), nil
+}
+
  // GetTopSuperNodesForBlock retrieves top supernodes for a specific block
 func (s *SuperNodeClient) GetTopSuperNodesForBlock(ctx context.Context, blockHeight int32) ([]*supernodetypes.SuperNode, error) {
 	resp, err := s.query.GetTopSuperNodesForBlock(ctx, &supernodetypes.QueryGetTopSuperNodesForBlockRequest{
@@ -128,7 +145,10 @@ func (s *SuperNodeClient) GetSuperNodeBySuperNodeAddress(ctx context.Context, su
 		SupernodeAddress: supernodeAddress,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get supernode by address: %w", err)
+		return nil, queryError("supernode by address", err)
+	}
+	if resp.Supernode == nil {
+		return nil, fmt.Errorf("supernode %s: %w", supernodeAddress, types.ErrNotFound)
 	}
 
 	return types.SuperNodeFromProto(resp.Supernode), nil
@@ -146,11 +166,7 @@ func (s *SuperNodeClient) ListSuperNodes(ctx context.Context, limit, offset uint
 		return nil, fmt.Errorf("failed to list supernodes: %w", err)
 	}
 
-	sns := make([]*types.SuperNode, len(resp.Supernodes))
-	for i, pb := range resp.Supernodes {
-		sns[i] = types.SuperNodeFromProto(pb)
-	}
-	return sns, nil
+	return types.SuperNodesFromProto(resp.Supernodes), nil
 }
 
 // GetTopSuperNodesForBlockWithOptions retrieves top supernodes for a block with optional limit and state filter.
@@ -164,16 +180,13 @@ func (s *SuperNodeClient) GetTopSuperNodesForBlockWithOptions(ctx context.Contex
 		return nil, fmt.Errorf("failed to get top supernodes: %w", err)
 	}
 
-	sns := make([]*types.SuperNode, len(resp.Supernodes))
-	for i, pb := range resp.Supernodes {
-		sns[i] = types.SuperNodeFromProto(pb)
-	}
-	return sns, nil
+	return types.SuperNodesFromProto(resp.Supernodes), nil
 }
 
 // -------- Transaction Helpers --------
 
 // UpdateSuperNodeParamsTx builds, signs, broadcasts and confirms a SuperNode MsgUpdateParams.
+// On a live chain the authority is the gov module; use ProposeSuperNodeParams there.
 func (c *Client) UpdateSuperNodeParamsTx(ctx context.Context, authority string, params supernodetypes.Params, memo string) (*types.ActionResult, error) {
 	msg := NewSuperNodeMsgUpdateParams(authority, params)
 
@@ -0,0 +1,42 @@
+package blockchain
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	supernodetypes "github.com/LumeraProtocol/lumera/x/supernode/v1/types"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/LumeraProtocol/sdk-go/types"
+)
+
+// fakeSuperNodeQuery fails lookups the way the chain does for unknown supernodes.
+type fakeSuperNodeQuery struct {
+	supernodetypes.QueryClient
+	err error
+}
+
+func (f *fakeSuperNodeQuery) GetSuperNode(context.Context, *supernodetypes.QueryGetSuperNodeRequest, ...grpc.CallOption) (*supernodetypes.QueryGetSuperNodeResponse, error) {
+	return nil, f.err
+}
+
+func (f *fakeSuperNodeQuery) GetSuperNodeBySuperNodeAddress(context.Context, *supernodetypes.QueryGetSuperNodeBySuperNodeAddressRequest, ...grpc.CallOption) (*supernodetypes.QueryGetSuperNodeBySuperNodeAddressResponse, error) {
+	return nil, f.err
+}
+
+func TestGetSuperNodeNotFound(t *testing.T) {
+	s := &SuperNodeClient{query: &fakeSuperNodeQuery{err: status.Error(codes.NotFound, "supernode not found")}}
+
+	_, err := s.GetSuperNode(context.Background(), "lumeravaloper1x")
+	require.True(t, errors.Is(err, types.ErrNotFound))
+	_, err = s.GetSuperNodeBySuperNodeAddress(context.Background(), "lumera1x")
+	require.True(t, errors.Is(err, types.ErrNotFound))
+
+	s.query = &fakeSuperNodeQuery{err: status.Error(codes.Unavailable, "down")}
+	_, err = s.GetSuperNode(context.Background(), "lumeravaloper1x")
+	require.False(t, errors.Is(err, types.ErrNotFound))
+}
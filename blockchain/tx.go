@@ -0,0 +1,51 @@
+package blockchain
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	txtypes "cosmossdk.io/api/cosmos/tx/v1beta1"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	gogoproto "github.com/cosmos/gogoproto/proto"
+)
+
+// sendMsg builds, signs, broadcasts and confirms a single-message transaction
+// and returns the included tx with its hash.
+func (c *Client) sendMsg(ctx context.Context, msg sdk.Msg, memo string) (*txtypes.GetTxResponse, string, error) {
+	txBytes, err := c.BuildAndSignTx(ctx, msg, memo)
+	if err != nil {
+		return nil, "", fmt.Errorf("build and sign tx: %w", err)
+	}
+
+	txHash, err := c.Broadcast(ctx, txBytes, txtypes.BroadcastMode_BROADCAST_MODE_SYNC)
+	if err != nil {
+		return nil, "", fmt.Errorf("broadcast tx: %w", err)
+	}
+
+	resp, err := c.WaitForTxInclusion(ctx, txHash)
+	if err != nil {
+		return nil, "", fmt.Errorf("wait for tx inclusion: %w", err)
+	}
+	return resp, txHash, nil
+}
+
+// decodeMsgResponse finds the message response whose type URL ends with
+// suffix in hex-encoded TxMsgData and unmarshals it into out.
+func decodeMsgResponse(dataHex, suffix string, out gogoproto.Message) error {
+	bz, err := hex.DecodeString(dataHex)
+	if err != nil {
+		return fmt.Errorf("decode tx data: %w", err)
+	}
+	var msgData sdk.TxMsgData
+	if err := gogoproto.Unmarshal(bz, &msgData); err != nil {
+		return fmt.Errorf("unmarshal tx data: %w", err)
+	}
+	for _, any := range msgData.MsgResponses {
+		if any != nil && strings.HasSuffix(any.TypeUrl, suffix) {
+			return gogoproto.Unmarshal(any.Value, out)
+		}
+	}
+	return fmt.Errorf("no %s in tx data", suffix)
+}
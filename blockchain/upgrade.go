@@ -0,0 +1,249 @@
+package blockchain
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	upgradetypes "cosmossdk.io/x/upgrade/types"
+	"github.com/cosmos/cosmos-sdk/client/grpc/cmtservice"
+
+	actiontypes "github.com/LumeraProtocol/lumera/x/action/v1/types"
+	audittypes "github.com/LumeraProtocol/lumera/x/audit/v1/types"
+
+	"github.com/LumeraProtocol/sdk-go/types"
+)
+
+// KnownModuleVersions are the consensus versions of the chain modules this SDK
+// was built against (lumera v1.11.1, cosmos-sdk v0.53, ibc-go v10). A chain
+// reporting a higher version may have changed messages or queries.
+var KnownModuleVersions = map[string]uint64{
+	"action":             actiontypes.ConsensusVersion,
+	"supernode":          1,
+	"claim":              1,
+	"audit":              audittypes.ConsensusVersion,
+	"auth":               5,
+	"bank":               4,
+	"staking":            5,
+	"distribution":       3,
+	"gov":                5,
+	"vesting":            1,
+	"transfer":           6,
+	"interchainaccounts": 3,
+}
+
+// VersionMismatch reports a chain module running a newer consensus version
+// than KnownModuleVersions.
+type VersionMismatch struct {
+	Module string
+	Known  uint64
+	Chain  uint64
+}
+
+func (m VersionMismatch) String() string {
+	return fmt.Sprintf("%s v%d (sdk knows v%d)", m.Module, m.Chain, m.Known)
+}
+
+// UpgradeClient provides upgrade module operations
+type UpgradeClient struct {
+	query upgradetypes.QueryClient
+	cmt   cmtservice.ServiceClient
+}
+
+// CurrentPlan returns the scheduled upgrade plan, or nil when none is scheduled.
+func (u *UpgradeClient) CurrentPlan(ctx context.Context) (*upgradetypes.Plan, error) {
+	resp, err := u.query.CurrentPlan(ctx, &upgradetypes.QueryCurrentPlanRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current plan: %w", err)
+	}
+	return resp.Plan, nil
+}
+
+// AppliedPlan returns the height at which the named upgrade was applied, or
+// 0 if it has not been applied.
+func (u *UpgradeClient) AppliedPlan(ctx context.Context, name string) (int64, error) {
+	resp, err := u.query.AppliedPlan(ctx, &upgradetypes.QueryAppliedPlanRequest{Name: name})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get applied plan: %w", err)
+	}
+	return resp.Height, nil
+}
+
+// ModuleVersions returns the consensus version of every module, or of the
+// named module only.
+func (u *UpgradeClient) ModuleVersions(ctx context.Context, module string) ([]upgradetypes.ModuleVersion, error) {
+	resp, err := u.query.ModuleVersions(ctx, &upgradetypes.QueryModuleVersionsRequest{ModuleName: module})
+	if err != nil {
+		return nil, queryError("module versions", err)
+	}
+	out := make([]upgradetypes.ModuleVersion, 0, len(resp.ModuleVersions))
+	for _, v := range resp.ModuleVersions {
+		if v != nil {
+			out = append(out, *v)
+		}
+	}
+	return out, nil
+}
+
+// CheckCompatibility compares the chain's module versions with
+// KnownModuleVersions and returns the modules that are newer on chain,
+// sorted by name. Modules the SDK does not use are ignored.
+func (u *UpgradeClient) CheckCompatibility(ctx context.Context) ([]VersionMismatch, error) {
+	versions, err := u.ModuleVersions(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	return compareModuleVersions(versions), nil
+}
+
+func compareModuleVersions(versions []upgradetypes.ModuleVersion) []VersionMismatch {
+	var out []VersionMismatch
+	for _, v := range versions {
+		known, ok := KnownModuleVersions[v.Name]
+		if ok && v.Version > known {
+			out = append(out, VersionMismatch{Module: v.Name, Known: known, Chain: v.Version})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Module < out[j].Module })
+	return out
+}
+
+// UpgradeNotice announces a scheduled upgrade close to the current height.
+type UpgradeNotice struct {
+	Name          string
+	Height        int64
+	Info          string
+	CurrentHeight int64
+	BlocksLeft    int64
+}
+
+// UpgradeWatcher polls the upgrade plan and notifies subscribers once per plan
+// when the chain comes within Threshold blocks of the upgrade height, so that
+// long-running work can pause before the chain halts.
+type UpgradeWatcher struct {
+	upgrade   *UpgradeClient
+	threshold int64
+	interval  time.Duration
+
+	mu       sync.Mutex
+	subs     map[int]func(UpgradeNotice)
+	nextID   int
+	notified map[string]bool
+	pending  *UpgradeNotice
+}
+
+// NewUpgradeWatcher creates a watcher. A threshold <= 0 uses 100 blocks and an
+// interval <= 0 polls every 30s.
+func NewUpgradeWatcher(u *UpgradeClient, threshold int64, interval time.Duration) *UpgradeWatcher {
+	if threshold <= 0 {
+		threshold = 100
+	}
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+	return &UpgradeWatcher{
+		upgrade:   u,
+		threshold: threshold,
+		interval:  interval,
+		subs:      make(map[int]func(UpgradeNotice)),
+		notified:  make(map[string]bool),
+	}
+}
+
+// Subscribe registers fn for upgrade notices and returns a function that
+// removes it. fn runs on the watcher goroutine and should not block.
+func (w *UpgradeWatcher) Subscribe(fn func(UpgradeNotice)) func() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	id := w.nextID
+	w.nextID++
+	w.subs[id] = fn
+	return func() {
+		w.mu.Lock()
+		delete(w.subs, id)
+		w.mu.Unlock()
+	}
+}
+
+// Pending returns the upgrade currently inside the threshold window, if any.
+func (w *UpgradeWatcher) Pending() (UpgradeNotice, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.pending == nil {
+		return UpgradeNotice{}, false
+	}
+	return *w.pending, true
+}
+
+// Run polls until ctx is done. Query errors are retried on the next tick.
+func (w *UpgradeWatcher) Run(ctx context.Context) error {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		_ = w.Poll(ctx)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll checks the plan once and notifies subscribers if needed.
+func (w *UpgradeWatcher) Poll(ctx context.Context) error {
+	plan, err := w.upgrade.CurrentPlan(ctx)
+	if err != nil {
+		return err
+	}
+	if plan == nil {
+		w.setPending(nil)
+		return nil
+	}
+	height, err := w.upgrade.latestHeight(ctx)
+	if err != nil {
+		return err
+	}
+	left := plan.Height - height
+	if left > w.threshold {
+		w.setPending(nil)
+		return nil
+	}
+	notice := UpgradeNotice{Name: plan.Name, Height: plan.Height, Info: plan.Info, CurrentHeight: height, BlocksLeft: left}
+	w.setPending(&notice)
+
+	w.mu.Lock()
+	if w.notified[plan.Name] {
+		w.mu.Unlock()
+		return nil
+	}
+	w.notified[plan.Name] = true
+	subs := make([]func(UpgradeNotice), 0, len(w.subs))
+	for _, fn := range w.subs {
+		subs = append(subs, fn)
+	}
+	w.mu.Unlock()
+
+	for _, fn := range subs {
+		fn(notice)
+	}
+	return nil
+}
+
+func (w *UpgradeWatcher) setPending(n *UpgradeNotice) {
+	w.mu.Lock()
+	w.pending = n
+	w.mu.Unlock()
+}
+
+func (u *UpgradeClient) latestHeight(ctx context.Context) (int64, error) {
+	resp, err := u.cmt.GetLatestBlock(ctx, &cmtservice.GetLatestBlockRequest{})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get latest block: %w", err)
+	}
+	if resp.SdkBlock == nil {
+		return 0, fmt.Errorf("empty latest block response: %w", types.ErrNotFound)
+	}
+	return resp.SdkBlock.Header.Height, nil
+}
@@ -0,0 +1,80 @@
+package blockchain
+
+import (
+	"context"
+	"testing"
+
+	upgradetypes "cosmossdk.io/x/upgrade/types"
+	"github.com/cosmos/cosmos-sdk/client/grpc/cmtservice"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+type fakeUpgradeQuery struct {
+	upgradetypes.QueryClient
+	plan     *upgradetypes.Plan
+	versions []*upgradetypes.ModuleVersion
+}
+
+func (f *fakeUpgradeQuery) CurrentPlan(_ context.Context, _ *upgradetypes.QueryCurrentPlanRequest, _ ...grpc.CallOption) (*upgradetypes.QueryCurrentPlanResponse, error) {
+	return &upgradetypes.QueryCurrentPlanResponse{Plan: f.plan}, nil
+}
+
+func (f *fakeUpgradeQuery) ModuleVersions(_ context.Context, _ *upgradetypes.QueryModuleVersionsRequest, _ ...grpc.CallOption) (*upgradetypes.QueryModuleVersionsResponse, error) {
+	return &upgradetypes.QueryModuleVersionsResponse{ModuleVersions: f.versions}, nil
+}
+
+type fakeLatestBlock struct {
+	cmtservice.ServiceClient
+	height int64
+}
+
+func (f *fakeLatestBlock) GetLatestBlock(_ context.Context, _ *cmtservice.GetLatestBlockRequest, _ ...grpc.CallOption) (*cmtservice.GetLatestBlockResponse, error) {
+	return &cmtservice.GetLatestBlockResponse{SdkBlock: &cmtservice.Block{Header: cmtservice.Header{Height: f.height}}}, nil
+}
+
+func TestCheckCompatibility(t *testing.T) {
+	u := &UpgradeClient{query: &fakeUpgradeQuery{versions: []*upgradetypes.ModuleVersion{
+		{Name: "staking", Version: 6},
+		{Name: "action", Version: 1},
+		{Name: "bank", Version: 5},
+		{Name: "mint", Version: 9},
+	}}}
+
+	got, err := u.CheckCompatibility(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []VersionMismatch{
+		{Module: "bank", Known: 4, Chain: 5},
+		{Module: "staking", Known: 5, Chain: 6},
+	}, got)
+}
+
+func TestUpgradeWatcherNotifiesOnce(t *testing.T) {
+	query := &fakeUpgradeQuery{plan: &upgradetypes.Plan{Name: "v2", Height: 1000, Info: "binaries"}}
+	cmt := &fakeLatestBlock{height: 850}
+	w := NewUpgradeWatcher(&UpgradeClient{query: query, cmt: cmt}, 100, 0)
+
+	var notices []UpgradeNotice
+	w.Subscribe(func(n UpgradeNotice) { notices = append(notices, n) })
+	ctx := context.Background()
+
+	require.NoError(t, w.Poll(ctx))
+	require.Empty(t, notices)
+	_, ok := w.Pending()
+	require.False(t, ok)
+
+	cmt.height = 920
+	require.NoError(t, w.Poll(ctx))
+	cmt.height = 930
+	require.NoError(t, w.Poll(ctx))
+	require.Len(t, notices, 1)
+	require.Equal(t, UpgradeNotice{Name: "v2", Height: 1000, Info: "binaries", CurrentHeight: 920, BlocksLeft: 80}, notices[0])
+	pending, ok := w.Pending()
+	require.True(t, ok)
+	require.Equal(t, int64(70), pending.BlocksLeft)
+
+	query.plan = nil
+	require.NoError(t, w.Poll(ctx))
+	_, ok = w.Pending()
+	require.False(t, ok)
+}
@@ -0,0 +1,533 @@
+package cascade
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	actiontypes "github.com/LumeraProtocol/lumera/x/action/v1/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/LumeraProtocol/sdk-go/blockchain"
+	sdkEvent "github.com/LumeraProtocol/sdk-go/cascade/event"
+	"github.com/LumeraProtocol/sdk-go/types"
+)
+
+// settleLookupTimeout bounds the final search for the action of a failed file.
+const settleLookupTimeout = 30 * time.Second
+
+// BatchStatus is the outcome of one file in a batch upload.
+type BatchStatus string
+
+const (
+	BatchStatusSucceeded BatchStatus = "succeeded"
+	BatchStatusFailed    BatchStatus = "failed"
+	// BatchStatusSkipped means the file was not registered because the
+	// batch budget was exhausted or the context was cancelled.
+	BatchStatusSkipped BatchStatus = "skipped"
+)
+
+// BatchOptions configures UploadBatch
+type BatchOptions struct {
+	Workers    int           // concurrent uploads (default 4)
+	Retries    int           // extra attempts per file after the first (default 2)
+	RetryDelay time.Duration // pause between attempts (default 2s)
+	// Budget caps the total action fees paid by the batch; the zero value
+	// means unlimited. Files whose fee would exceed it are skipped.
+	Budget sdk.Coin
+	// ManifestPath, when set, receives the manifest after the batch; the
+	// format follows the extension (.csv, otherwise JSON).
+	ManifestPath  string
+	UploadOptions []UploadOption
+}
+
+// BatchOption is a functional option for UploadBatch
+type BatchOption func(*BatchOptions)
+
+// WithWorkers sets the number of concurrent uploads.
+func WithWorkers(n int) BatchOption {
+	return func(o *BatchOptions) {
+		o.Workers = n
+	}
+}
+
+// WithRetries sets the extra attempts per file and the delay between them.
+func WithRetries(n int, delay time.Duration) BatchOption {
+	return func(o *BatchOptions) {
+		o.Retries = n
+		o.RetryDelay = delay
+	}
+}
+
+// WithBudget caps the total action fees paid by the batch.
+func WithBudget(budget sdk.Coin) BatchOption {
+	return func(o *BatchOptions) {
+		o.Budget = budget
+	}
+}
+
+// WithManifest writes the batch manifest to path.
+func WithManifest(path string) BatchOption {
+	return func(o *BatchOptions) {
+		o.ManifestPath = path
+	}
+}
+
+// WithBatchUploadOptions applies upload options to every file. WithID is
+// ignored since each upload needs its own ID.
+func WithBatchUploadOptions(opts ...UploadOption) BatchOption {
+	return func(o *BatchOptions) {
+		o.UploadOptions = append(o.UploadOptions, opts...)
+	}
+}
+
+// ManifestEntry records the outcome of one file.
+type ManifestEntry struct {
+	FilePath string      `json:"file_path"`
+	Size     int64       `json:"size"`
+	DataHash string      `json:"data_hash,omitempty"`
+	Price    string      `json:"price,omitempty"`
+	ActionID string      `json:"action_id,omitempty"`
+	TxHash   string      `json:"tx_hash,omitempty"`
+	TaskID   string      `json:"task_id,omitempty"`
+	Status   BatchStatus `json:"status"`
+	Attempts int         `json:"attempts"`
+	Error    string      `json:"error,omitempty"`
+}
+
+// BatchResult is the manifest of a batch upload, in input order.
+type BatchResult struct {
+	Entries   []ManifestEntry `json:"entries"`
+	Succeeded int             `json:"succeeded"`
+	Failed    int             `json:"failed"`
+	Skipped   int             `json:"skipped"`
+	// Spent is the total fee of the actions registered by the batch.
+	Spent sdk.Coins `json:"spent"`
+}
+
+// UploadBatch uploads files concurrently. Each path may be a file or a
+// directory, whose regular files are uploaded recursively. Registration txs
+// are sent one at a time since they share the creator's account sequence;
+// metadata building and supernode uploads run in parallel. Files are retried
+// individually; a file whose action was already registered is retried from
+// the supernode upload without paying again. Progress is emitted as
+// SDKGoBatchProgress events and SDKGoBatchCompleted at the end.
+//
+// The result lists every file. The error is non-nil when listing the input
+// or writing the manifest fails, or when some files did not succeed.
+func (c *Client) UploadBatch(ctx context.Context, creator string, bc *blockchain.Client, paths []string, opts ...BatchOption) (*BatchResult, error) {
+	options := &BatchOptions{Workers: 4, Retries: 2, RetryDelay: 2 * time.Second}
+	for _, opt := range opts {
+		opt(options)
+	}
+	if options.Workers <= 0 {
+		options.Workers = 1
+	}
+	if options.Retries < 0 {
+		options.Retries = 0
+	}
+	files, err := expandBatchPaths(paths)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no files to upload")
+	}
+
+	b := &batch{
+		c:       c,
+		bc:      bc,
+		creator: creator,
+		options: options,
+		result:  &BatchResult{Entries: make([]ManifestEntry, len(files)), Spent: sdk.NewCoins()},
+	}
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < options.Workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				b.run(ctx, i, files[i])
+			}
+		}()
+	}
+	for i := range files {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	res := b.result
+	c.emitClientEvent(ctx, sdkEvent.Event{
+		Type:      sdkEvent.SDKGoBatchCompleted,
+		TaskType:  string(types.ActionTypeCascade),
+		Timestamp: time.Now(),
+		Data:      b.progressData(),
+	})
+	if options.ManifestPath != "" {
+		if err := res.WriteManifest(options.ManifestPath); err != nil {
+			return res, err
+		}
+	}
+	if res.Succeeded != len(files) {
+		return res, fmt.Errorf("%d of %d uploads did not succeed (%d failed, %d skipped)", len(files)-res.Succeeded, len(files), res.Failed, res.Skipped)
+	}
+	return res, nil
+}
+
+// batch holds the shared state of one UploadBatch call.
+type batch struct {
+	c       *Client
+	bc      *blockchain.Client
+	creator string
+	options *BatchOptions
+
+	mu       sync.Mutex
+	result   *BatchResult
+	done     int
+	reserved sdk.Coins
+
+	// regMu serializes registrations: they are signed with the creator's
+	// key, and concurrent txs would collide on the account sequence.
+	regMu sync.Mutex
+}
+
+func (b *batch) run(ctx context.Context, i int, path string) {
+	me := ManifestEntry{FilePath: path}
+	if info, err := os.Stat(path); err == nil {
+		me.Size = info.Size()
+	}
+	res, entry, err := b.upload(ctx, &me)
+	switch {
+	case err == nil:
+		me.Status = BatchStatusSucceeded
+		me.ActionID, me.TxHash, me.TaskID = res.ActionID, res.TxHash, res.TaskID
+	case entry == nil || entry.Stage == JournalStageMetadataBuilt:
+		// Nothing was paid for this file.
+		me.Status = BatchStatusFailed
+		if errIsSkip(err) {
+			me.Status = BatchStatusSkipped
+		}
+		me.Error = err.Error()
+	default:
+		me.Status = BatchStatusFailed
+		me.ActionID, me.TxHash, me.TaskID = entry.ActionID, entry.TxHash, entry.TaskID
+		me.Error = err.Error()
+	}
+
+	b.mu.Lock()
+	b.result.Entries[i] = me
+	b.done++
+	switch me.Status {
+	case BatchStatusSucceeded:
+		b.result.Succeeded++
+	case BatchStatusFailed:
+		b.result.Failed++
+	case BatchStatusSkipped:
+		b.result.Skipped++
+	}
+	data := b.progressDataLocked()
+	b.mu.Unlock()
+
+	data[sdkEvent.KeyFilePath] = path
+	if me.Error != "" {
+		data[sdkEvent.KeyError] = me.Error
+	}
+	b.c.emitClientEvent(ctx, sdkEvent.Event{
+		Type:      sdkEvent.SDKGoBatchProgress,
+		TaskType:  string(types.ActionTypeCascade),
+		ActionID:  me.ActionID,
+		TaskID:    me.TaskID,
+		Timestamp: time.Now(),
+		Data:      data,
+	})
+}
+
+// skipError marks failures that happen before anything is attempted.
+type skipError struct{ error }
+
+func errIsSkip(err error) bool {
+	_, ok := err.(skipError)
+	return ok
+}
+
+// upload runs the attempts for one file. The journal entry carries the
+// progress between attempts, and before registering again the chain is
+// checked for the action of an earlier attempt, so an action is never paid
+// twice.
+func (b *batch) upload(ctx context.Context, me *ManifestEntry) (*types.CascadeResult, *JournalEntry, error) {
+	options := newUploadOptions(b.options.UploadOptions)
+	options.ID = ""
+
+	var entry *JournalEntry
+	var msg *actiontypes.MsgRequestAction
+	var meta []byte
+	var price sdk.Coin
+	var lastErr error
+	sent := false // a registration tx may have been broadcast
+	for attempt := 0; attempt <= b.options.Retries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return nil, b.settle(ctx, entry, price, sent, options), lastErr
+			case <-time.After(b.options.RetryDelay):
+			}
+		}
+		if ctx.Err() != nil {
+			if entry == nil {
+				return nil, nil, skipError{ctx.Err()}
+			}
+			return nil, b.settle(ctx, entry, price, sent, options), ctx.Err()
+		}
+		me.Attempts++
+		if entry == nil {
+			var err error
+			entry, msg, meta, err = b.c.prepareUpload(ctx, b.creator, me.FilePath, "", options, false)
+			if err != nil {
+				lastErr = err
+				continue
+			}
+			me.DataHash = metadataDataHash(entry.Metadata)
+			me.Price = entry.Price
+			if price, err = b.reserve(entry.Price); err != nil {
+				if options.Journal != nil {
+					_ = options.Journal.Remove(entry.ID)
+				}
+				return nil, nil, skipError{err}
+			}
+		}
+		if entry.Stage == JournalStageMetadataBuilt {
+			if err := b.register(ctx, entry, msg, meta, options, &sent); err != nil {
+				lastErr = err
+				continue
+			}
+		}
+		res, err := b.c.continueUpload(ctx, b.bc, entry, msg, meta, options)
+		if err == nil {
+			b.spend(price)
+			return res, entry, nil
+		}
+		lastErr = err
+	}
+	return nil, b.settle(ctx, entry, price, sent, options), lastErr
+}
+
+// register sends the request action tx of entry, one file at a time. When
+// an earlier attempt sent one, the chain is searched for its action first.
+func (b *batch) register(ctx context.Context, entry *JournalEntry, msg *actiontypes.MsgRequestAction, meta []byte, options *UploadOptions, sent *bool) error {
+	b.regMu.Lock()
+	defer b.regMu.Unlock()
+	if *sent {
+		found, err := b.findRegistered(ctx, entry, options)
+		if err != nil || found {
+			return err
+		}
+	}
+	*sent = true
+	return b.c.register(ctx, b.bc, entry, msg, meta, options)
+}
+
+// findRegistered advances entry when the chain holds the action of an
+// earlier registration attempt.
+func (b *batch) findRegistered(ctx context.Context, entry *JournalEntry, options *UploadOptions) (bool, error) {
+	actions := b.c.actionQuerier(b.bc)
+	if actions == nil {
+		return false, fmt.Errorf("cannot check for an earlier registration without a blockchain client")
+	}
+	found, err := b.c.findRegistration(ctx, b.bc, actions, entry)
+	if err != nil {
+		return false, fmt.Errorf("check earlier registration: %w", err)
+	}
+	if !found {
+		return false, nil
+	}
+	b.c.logf("cascade: batch found registered action %s for %s", entry.ActionID, entry.FilePath)
+	return true, b.c.markRegistered(entry, options)
+}
+
+// settle accounts for the fee of a file that did not succeed. A file whose
+// registration may have gone through is looked up once more; if that is
+// not possible its fee is counted as spent.
+func (b *batch) settle(ctx context.Context, entry *JournalEntry, price sdk.Coin, sent bool, options *UploadOptions) *JournalEntry {
+	if entry == nil {
+		return nil
+	}
+	if entry.Stage == JournalStageMetadataBuilt && sent {
+		lookupCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), settleLookupTimeout)
+		b.regMu.Lock()
+		_, err := b.findRegistered(lookupCtx, entry, options)
+		b.regMu.Unlock()
+		cancel()
+		if err != nil {
+			b.c.logf("cascade: batch %s: %v", entry.FilePath, err)
+			b.spend(price)
+			return entry
+		}
+	}
+	if entry.Stage == JournalStageMetadataBuilt {
+		b.unreserve(price)
+	} else {
+		b.spend(price)
+	}
+	return entry
+}
+
+// reserve holds price against the budget until the upload registers or fails.
+func (b *batch) reserve(priceStr string) (sdk.Coin, error) {
+	price, err := sdk.ParseCoinNormalized(priceStr)
+	if err != nil {
+		return sdk.Coin{}, fmt.Errorf("parse price %q: %w", priceStr, err)
+	}
+	if b.options.Budget.IsNil() || b.options.Budget.IsZero() {
+		return price, nil
+	}
+	if price.Denom != b.options.Budget.Denom {
+		return sdk.Coin{}, fmt.Errorf("price %s is not in budget denom %s", price, b.options.Budget.Denom)
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	committed := b.result.Spent.Add(b.reserved...).AmountOf(price.Denom)
+	if committed.Add(price.Amount).GT(b.options.Budget.Amount) {
+		return sdk.Coin{}, fmt.Errorf("fee %s exceeds remaining budget of %s", price, b.options.Budget.SubAmount(committed))
+	}
+	b.reserved = b.reserved.Add(price)
+	return price, nil
+}
+
+func (b *batch) unreserve(price sdk.Coin) {
+	if b.options.Budget.IsNil() || b.options.Budget.IsZero() || !price.IsPositive() {
+		return
+	}
+	b.mu.Lock()
+	b.reserved = b.reserved.Sub(price)
+	b.mu.Unlock()
+}
+
+func (b *batch) spend(price sdk.Coin) {
+	b.unreserve(price)
+	if !price.IsPositive() {
+		return
+	}
+	b.mu.Lock()
+	b.result.Spent = b.result.Spent.Add(price)
+	b.mu.Unlock()
+}
+
+func (b *batch) progressData() sdkEvent.EventData {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.progressDataLocked()
+}
+
+func (b *batch) progressDataLocked() sdkEvent.EventData {
+	total := len(b.result.Entries)
+	return sdkEvent.EventData{
+		sdkEvent.KeyCount:     b.done,
+		sdkEvent.KeyTotal:     total,
+		sdkEvent.KeyProgress:  b.done * 100 / total,
+		sdkEvent.KeySucceeded: b.result.Succeeded,
+		sdkEvent.KeyFailed:    b.result.Failed,
+		sdkEvent.KeySkipped:   b.result.Skipped,
+		sdkEvent.KeySpent:     b.result.Spent.String(),
+	}
+}
+
+func metadataDataHash(metadata string) string {
+	var cm actiontypes.CascadeMetadata
+	if err := json.Unmarshal([]byte(metadata), &cm); err != nil {
+		return ""
+	}
+	return cm.DataHash
+}
+
+// expandBatchPaths resolves directories to their regular files, sorted.
+func expandBatchPaths(paths []string) ([]string, error) {
+	var out []string
+	for _, p := range paths {
+		info, err := os.Stat(p)
+		if err != nil {
+			return nil, fmt.Errorf("batch input: %w", err)
+		}
+		if !info.IsDir() {
+			out = append(out, p)
+			continue
+		}
+		var found []string
+		err = filepath.WalkDir(p, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.Type().IsRegular() {
+				found = append(found, path)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("walk %s: %w", p, err)
+		}
+		sort.Strings(found)
+		out = append(out, found...)
+	}
+	return out, nil
+}
+
+// WriteJSON writes the manifest as indented JSON.
+func (r *BatchResult) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// manifestHeader lists the CSV columns written by WriteCSV.
+var manifestHeader = []string{"file_path", "size", "data_hash", "price", "action_id", "tx_hash", "task_id", "status", "attempts", "error"}
+
+// WriteCSV writes one row per file with a header row.
+func (r *BatchResult) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(manifestHeader); err != nil {
+		return err
+	}
+	for _, e := range r.Entries {
+		row := []string{
+			e.FilePath, strconv.FormatInt(e.Size, 10), e.DataHash, e.Price,
+			e.ActionID, e.TxHash, e.TaskID, string(e.Status), strconv.Itoa(e.Attempts), e.Error,
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteManifest writes the manifest to path, as CSV for a .csv extension and
+// JSON otherwise.
+func (r *BatchResult) WriteManifest(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("create manifest: %w", err)
+	}
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		err = r.WriteCSV(f)
+	} else {
+		err = r.WriteJSON(f)
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("write manifest: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package cascade
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	actiontypes "github.com/LumeraProtocol/lumera/x/action/v1/types"
+	"github.com/LumeraProtocol/supernode/v2/sdk/task"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	sdkEvent "github.com/LumeraProtocol/sdk-go/cascade/event"
+	"github.com/LumeraProtocol/sdk-go/types"
+)
+
+// fakeBatchSN fails the first supernode upload of files named in flaky.
+type fakeBatchSN struct {
+	fakeSNClient
+	mu     sync.Mutex
+	flaky  map[string]bool
+	starts map[string]int
+}
+
+func (f *fakeBatchSN) BuildCascadeMetadataFromFile(_ context.Context, filePath string, _ bool, _ string) (actiontypes.CascadeMetadata, string, string, error) {
+	return actiontypes.CascadeMetadata{DataHash: "hash-" + filepath.Base(filePath), FileName: filepath.Base(filePath)}, "10ulume", "99999999999", nil
+}
+
+func (f *fakeBatchSN) StartCascade(_ context.Context, filePath string, actionID string, _ string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	name := filepath.Base(filePath)
+	f.starts[name]++
+	if f.flaky[name] && f.starts[name] == 1 {
+		return "", errors.New("supernode busy")
+	}
+	return "task-" + actionID, nil
+}
+
+func (f *fakeBatchSN) GetTask(_ context.Context, taskID string) (*task.TaskEntry, bool) {
+	return &task.TaskEntry{TaskID: taskID, Status: task.StatusCompleted}, true
+}
+
+func TestUploadBatch(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a.txt", "b.txt", "c.txt"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(name), 0o600))
+	}
+	sn := &fakeBatchSN{flaky: map[string]bool{"b.txt": true}, starts: map[string]int{}}
+	c := &Client{snClient: sn, tasks: NewTaskManager(sn), localSubs: make(map[sdkEvent.EventType][]sdkEvent.Handler)}
+
+	var progress []sdkEvent.EventData
+	require.NoError(t, c.SubscribeToEvents(context.Background(), sdkEvent.SDKGoBatchProgress, func(_ context.Context, e sdkEvent.Event) {
+		progress = append(progress, e.Data)
+	}))
+
+	var registered []string
+	send := func(_ context.Context, _ *actiontypes.MsgRequestAction, _ []byte, filePath string, _ *UploadOptions) (*types.ActionResult, error) {
+		registered = append(registered, filepath.Base(filePath))
+		return &types.ActionResult{ActionID: "id-" + filepath.Base(filePath), TxHash: "tx"}, nil
+	}
+
+	manifest := filepath.Join(t.TempDir(), "manifest.csv")
+	res, err := c.UploadBatch(context.Background(), "creator", nil, []string{dir},
+		WithWorkers(1),
+		WithRetries(1, time.Millisecond),
+		WithBudget(sdk.NewInt64Coin("ulume", 25)),
+		WithManifest(manifest),
+		WithBatchUploadOptions(WithICASendFunc(send)),
+	)
+	require.ErrorContains(t, err, "1 of 3 uploads did not succeed")
+	require.Equal(t, 2, res.Succeeded)
+	require.Equal(t, 1, res.Skipped)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("ulume", 20)), res.Spent)
+
+	// b.txt was retried from the supernode upload without registering again.
+	require.Equal(t, []string{"a.txt", "b.txt"}, registered)
+	require.Equal(t, 2, sn.starts["b.txt"])
+	b := res.Entries[1]
+	require.Equal(t, BatchStatusSucceeded, b.Status)
+	require.Equal(t, 2, b.Attempts)
+	require.Equal(t, "hash-b.txt", b.DataHash)
+	require.Equal(t, "task-id-b.txt", b.TaskID)
+	require.Equal(t, int64(5), b.Size)
+	require.Equal(t, BatchStatusSkipped, res.Entries[2].Status)
+	require.Contains(t, res.Entries[2].Error, "budget")
+
+	require.Len(t, progress, 3)
+	require.Equal(t, 3, progress[2][sdkEvent.KeyCount])
+	require.Equal(t, 100, progress[2][sdkEvent.KeyProgress])
+
+	f, err := os.Open(manifest)
+	require.NoError(t, err)
+	defer f.Close()
+	rows, err := csv.NewReader(f).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 4)
+	require.Equal(t, manifestHeader, rows[0])
+	require.Equal(t, "succeeded", rows[1][7])
+
+	var buf bytes.Buffer
+	require.NoError(t, res.WriteJSON(&buf))
+	var decoded BatchResult
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	require.Equal(t, res.Entries, decoded.Entries)
+	require.True(t, strings.HasSuffix(decoded.Entries[0].FilePath, "a.txt"))
+}
+
+func TestUploadBatchRegistration(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a.txt", "b.txt", "c.txt", "d.txt"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(name), 0o600))
+	}
+	sn := &fakeBatchSN{starts: map[string]int{}}
+	actions := &fakeActions{}
+	c := &Client{snClient: sn, tasks: NewTaskManager(sn), actions: actions, localSubs: make(map[sdkEvent.EventType][]sdkEvent.Handler)}
+
+	// a.txt's tx is included but confirming it times out.
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	var registered []string
+	send := func(_ context.Context, _ *actiontypes.MsgRequestAction, _ []byte, filePath string, _ *UploadOptions) (*types.ActionResult, error) {
+		mu.Lock()
+		inFlight++
+		maxInFlight = max(maxInFlight, inFlight)
+		name := filepath.Base(filePath)
+		registered = append(registered, name)
+		mu.Unlock()
+		time.Sleep(5 * time.Millisecond)
+		mu.Lock()
+		defer mu.Unlock()
+		inFlight--
+		if name == "a.txt" {
+			actions.found = []*types.Action{{ID: "id-a.txt", Creator: "creator", Metadata: &types.CascadeMetadata{DataHash: "hash-a.txt"}}}
+			return nil, errors.New("wait for tx inclusion: timeout")
+		}
+		return &types.ActionResult{ActionID: "id-" + name, TxHash: "tx"}, nil
+	}
+
+	res, err := c.UploadBatch(context.Background(), "creator", nil, []string{dir},
+		WithWorkers(4),
+		WithRetries(1, time.Millisecond),
+		WithBatchUploadOptions(WithICASendFunc(send)),
+	)
+	require.NoError(t, err)
+	require.Equal(t, 1, maxInFlight)
+	require.Len(t, registered, 4)
+	require.Equal(t, "id-a.txt", res.Entries[0].ActionID)
+	require.Equal(t, 2, res.Entries[0].Attempts)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("ulume", 40)), res.Spent)
+}
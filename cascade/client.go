@@ -8,6 +8,7 @@ import (
 	"sync"
 	"time"
 
+	actiontypes "github.com/LumeraProtocol/lumera/x/action/v1/types"
 	"github.com/LumeraProtocol/lumera/x/lumeraid/securekeyx"
 	snsdk "github.com/LumeraProtocol/supernode/v2/sdk/action"
 	snconfig "github.com/LumeraProtocol/supernode/v2/sdk/config"
@@ -15,7 +16,9 @@ import (
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 
+	"github.com/LumeraProtocol/sdk-go/blockchain"
 	sdkEvent "github.com/LumeraProtocol/sdk-go/cascade/event"
+	"github.com/LumeraProtocol/sdk-go/types"
 )
 
 // Config for a cascade client
@@ -28,9 +31,17 @@ type Config struct {
 	ICAOwnerKeyName string
 	// ICAOwnerHRP is the bech32 prefix for the ICA controller owner chain.
 	ICAOwnerHRP string
-	Timeout     time.Duration
+	// Timeout bounds the wait for each supernode upload or download task
+	// (0 => no limit beyond the SuperNode SDK's own). A journaled upload that
+	// times out stays at task_started; Resume checks the action on chain and
+	// only uploads again if the supernodes did not finish.
+	Timeout time.Duration
 	// LogLevel controls SDK logging (debug, info, warn, error). Default is error.
 	LogLevel string
+	// SpoolDir holds temporary files for UploadReader/UploadBytes. Default is os.TempDir().
+	SpoolDir string
+	// MaxSpoolBytes caps the bytes spooled by concurrent uploads (0 => unlimited).
+	MaxSpoolBytes int64
 }
 
 // Client provides access to cascade operations (wraps SuperNode SDK)
@@ -42,6 +53,14 @@ type Client struct {
 	logger   *zap.Logger
 	snLogger *supernodeLogger
 
+	spoolOnce sync.Once
+	spool     *spooler
+
+	// actions provides the metadata used to verify downloads and resume uploads.
+	actions actionQuerier
+	// txs looks up the journaled registration txs of resumed uploads.
+	txs registrationTxQuerier
+
 	subMu        sync.RWMutex
 	localSubs    map[sdkEvent.EventType][]sdkEvent.Handler
 	localSubsAll []sdkEvent.Handler
@@ -82,7 +101,7 @@ func New(ctx context.Context, cfg Config, kr keyring.Keyring) (*Client, error) {
 	}
 
 	// Create a task manager
-	taskMgr := NewTaskManager(snClient)
+	taskMgr := NewTaskManager(snClient, WithTaskTimeout(cfg.Timeout))
 
 	return &Client{
 		snClient:  snClient, // store single-level pointer
@@ -95,6 +114,34 @@ func New(ctx context.Context, cfg Config, kr keyring.Keyring) (*Client, error) {
 	}, nil
 }
 
+// actionQuerier is the part of blockchain.ActionClient used by the cascade client.
+type actionQuerier interface {
+	GetAction(ctx context.Context, actionID string) (*types.Action, error)
+	QueryActionByMetadataEnum(ctx context.Context, actionType actiontypes.ActionType, metadataQuery string, limit, offset uint64) ([]*types.Action, error)
+}
+
+// registrationTxQuerier is the part of blockchain.Client used to settle a
+// registration tx whose confirmation was not observed.
+type registrationTxQuerier interface {
+	RequestActionTxResult(ctx context.Context, txHash string) (*types.ActionResult, error)
+}
+
+// SetBlockchain provides the chain access Download needs to verify content
+// hashes and Resume needs to check actions. client.New sets it automatically.
+func (c *Client) SetBlockchain(bc *blockchain.Client) {
+	if bc != nil {
+		c.actions = bc.Action
+		c.txs = bc
+	}
+}
+
+func (c *Client) spooler() *spooler {
+	c.spoolOnce.Do(func() {
+		c.spool = newSpooler(c.config.SpoolDir, c.config.MaxSpoolBytes)
+	})
+	return c.spool
+}
+
 // SetLogger configures optional diagnostics logging.
 func (c *Client) SetLogger(logger *zap.Logger) {
 	c.logger = logger
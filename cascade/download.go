@@ -2,18 +2,26 @@ package cascade
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
 	sdkEvent "github.com/LumeraProtocol/sdk-go/cascade/event"
+	"github.com/LumeraProtocol/sdk-go/internal/utils"
 	sdkcrypto "github.com/LumeraProtocol/sdk-go/pkg/crypto"
 	"github.com/LumeraProtocol/sdk-go/types"
+	snTask "github.com/LumeraProtocol/supernode/v2/sdk/task"
 )
 
 // DownloadOptions configures cascade download
 type DownloadOptions struct {
 	SignerAddr string
+	// Progress, when set, receives progress reports for this download.
+	Progress func(Progress)
 }
 
 // DownloadOption is a functional option for Download
@@ -26,14 +34,99 @@ func WithDownloadSignerAddress(addr string) DownloadOption {
 	}
 }
 
-// Download downloads a file from Cascade
+// WithDownloadProgress reports the progress of this download to fn. fn runs
+// on the calling goroutine and should return quickly.
+func WithDownloadProgress(fn func(Progress)) DownloadOption {
+	return func(opts *DownloadOptions) {
+		opts.Progress = fn
+	}
+}
+
+// Download downloads a file from Cascade into outputDir/<actionID>/<file name>
+// and verifies it against the action's data hash. A corrupt file is left in
+// place and reported as a *types.IntegrityError.
 func (c *Client) Download(ctx context.Context, actionID string, outputDir string, opts ...DownloadOption) (*types.DownloadResult, error) {
-	// Apply options
 	options := &DownloadOptions{}
 	for _, opt := range opts {
 		opt(options)
 	}
+	meta, sizeKbs, err := c.cascadeMetadata(ctx, actionID)
+	if err != nil {
+		return nil, err
+	}
+	return c.download(ctx, actionID, outputDir, meta, sizeKbs, options)
+}
+
+// DownloadTo downloads a file from Cascade and writes it to w once its data
+// hash has been verified. The file is staged under Config.SpoolDir and counts
+// against Config.MaxSpoolBytes while the download runs.
+func (c *Client) DownloadTo(ctx context.Context, actionID string, w io.Writer, opts ...DownloadOption) (*types.DownloadResult, error) {
+	if w == nil {
+		return nil, fmt.Errorf("writer is required")
+	}
+	options := &DownloadOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	meta, sizeKbs, err := c.cascadeMetadata(ctx, actionID)
+	if err != nil {
+		return nil, err
+	}
+
+	sp := c.spooler()
+	reserved := sizeKbs * 1024
+	if err := sp.reserve(reserved); err != nil {
+		return nil, err
+	}
+	defer sp.release(reserved)
+	dir, err := os.MkdirTemp(sp.dir, "lumera-download-*")
+	if err != nil {
+		return nil, fmt.Errorf("create download dir: %w", err)
+	}
+	defer os.RemoveAll(dir) //nolint:errcheck
+
+	res, err := c.download(ctx, actionID, dir, meta, sizeKbs, options)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(res.OutputPath)
+	if err != nil {
+		return nil, fmt.Errorf("open downloaded file: %w", err)
+	}
+	defer f.Close() //nolint:errcheck
+	if _, err := io.Copy(w, f); err != nil {
+		return nil, fmt.Errorf("write downloaded file: %w", err)
+	}
+	res.OutputPath = ""
+	return res, nil
+}
+
+// cascadeMetadata looks up the action being downloaded; its data hash is
+// needed to verify the result.
+func (c *Client) cascadeMetadata(ctx context.Context, actionID string) (*types.CascadeMetadata, int64, error) {
+	if actionID == "" {
+		return nil, 0, fmt.Errorf("actionID is required")
+	}
+	if c.actions == nil {
+		return nil, 0, fmt.Errorf("download verification needs a blockchain client; call SetBlockchain")
+	}
+	action, err := c.actions.GetAction(ctx, actionID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("get action %s: %w", actionID, err)
+	}
+	meta, ok := action.Metadata.(*types.CascadeMetadata)
+	if !ok || meta == nil {
+		return nil, 0, fmt.Errorf("action %s is not a cascade action: %w", actionID, types.ErrInvalidMetadata)
+	}
+	if meta.DataHash == "" || meta.FileName == "" || filepath.Base(meta.FileName) != meta.FileName {
+		return nil, 0, fmt.Errorf("action %s has no usable data hash or file name: %w", actionID, types.ErrInvalidMetadata)
+	}
+	return meta, action.FileSizeKbs, nil
+}
 
+func (c *Client) download(ctx context.Context, actionID, outputDir string, meta *types.CascadeMetadata, sizeKbs int64, options *DownloadOptions) (*types.DownloadResult, error) {
+	progress := newProgressTracker(options.Progress)
+	progress.setIDs(actionID, "")
 	taskType := string(types.ActionTypeCascade)
 	c.logf("cascade: starting download, action=%s dest=%s", actionID, outputDir)
 	c.emitClientEvent(ctx, sdkEvent.Event{
@@ -83,16 +176,39 @@ func (c *Client) Download(ctx context.Context, actionID string, outputDir string
 		return nil, fmt.Errorf("failed to start download: %w", err)
 	}
 
-	// Wait for completion
-	task, err := c.tasks.Wait(ctx, taskID)
+	// The supernode SDK writes to <outputDir>/<actionID>/<file name>
+	outputPath := filepath.Join(outputDir, actionID, meta.FileName)
+
+	// Wait for completion; the file size tracks the bytes received so far.
+	var onTick func(*snTask.TaskEntry)
+	if progress != nil {
+		progress.setIDs("", taskID)
+		progress.stage(ProgressDownloading, "Cascade download task started")
+		onTick = func(entry *snTask.TaskEntry) {
+			progress.task(entry)
+			if info, err := os.Stat(outputPath); err == nil {
+				progress.bytes(info.Size(), sizeKbs*1024)
+			}
+		}
+	}
+	task, err := c.tasks.wait(ctx, taskID, onTick)
 	if err != nil {
 		return nil, fmt.Errorf("download failed: %w", err)
 	}
 
+	if info, err := os.Stat(outputPath); err == nil {
+		progress.bytes(info.Size(), info.Size())
+	}
+	progress.stage(ProgressVerifying, "Verifying data hash")
+	if err := verifyDownload(actionID, outputPath, meta.DataHash); err != nil {
+		return nil, err
+	}
+	progress.stage(ProgressCompleted, "Cascade download completed")
+
 	result := &types.DownloadResult{
 		ActionID:   actionID,
 		TaskID:     task.TaskID,
-		OutputPath: outputDir + "/" + actionID,
+		OutputPath: outputPath,
 	}
 
 	c.logf("cascade: download completed action_id=%s task_id=%s", actionID, taskID)
@@ -109,3 +225,22 @@ func (c *Client) Download(ctx context.Context, actionID string, outputDir string
 
 	return result, nil
 }
+
+// verifyDownload checks the BLAKE3 hash of path against the base64 dataHash
+// from the action metadata.
+func verifyDownload(actionID, path, dataHash string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("downloaded file: %w", err)
+	}
+	defer f.Close() //nolint:errcheck
+	sum, err := utils.HashReader(f)
+	if err != nil {
+		return fmt.Errorf("hash downloaded file: %w", err)
+	}
+	actual := base64.StdEncoding.EncodeToString(sum)
+	if actual != dataHash {
+		return &types.IntegrityError{ActionID: actionID, Path: path, Expected: dataHash, Actual: actual}
+	}
+	return nil
+}
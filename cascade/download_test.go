@@ -0,0 +1,122 @@
+package cascade
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	actiontypes "github.com/LumeraProtocol/lumera/x/action/v1/types"
+	"github.com/LumeraProtocol/supernode/v2/sdk/task"
+	"github.com/stretchr/testify/require"
+	"lukechampine.com/blake3"
+
+	"github.com/LumeraProtocol/sdk-go/types"
+)
+
+// fakeDownloadSN writes content where the supernode SDK would place the file.
+type fakeDownloadSN struct {
+	fakeSNClient
+	fileName string
+	content  []byte
+}
+
+func (f *fakeDownloadSN) DownloadCascade(_ context.Context, actionID, outputDir, _ string) (string, error) {
+	dir := filepath.Join(outputDir, actionID)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	return "task-1", os.WriteFile(filepath.Join(dir, f.fileName), f.content, 0o600)
+}
+
+func (f *fakeDownloadSN) GetTask(_ context.Context, taskID string) (*task.TaskEntry, bool) {
+	return &task.TaskEntry{TaskID: taskID, Status: task.StatusCompleted}, true
+}
+
+type fakeActions struct {
+	action  *types.Action
+	found   []*types.Action
+	queries int
+}
+
+func (f *fakeActions) GetAction(_ context.Context, _ string) (*types.Action, error) {
+	if f.action == nil {
+		return nil, types.ErrNotFound
+	}
+	return f.action, nil
+}
+
+func (f *fakeActions) QueryActionByMetadataEnum(_ context.Context, _ actiontypes.ActionType, _ string, limit, offset uint64) ([]*types.Action, error) {
+	f.queries++
+	if offset >= uint64(len(f.found)) {
+		return nil, nil
+	}
+	return f.found[offset:min(offset+limit, uint64(len(f.found)))], nil
+}
+
+func newDownloadClient(t *testing.T, content []byte, dataHash string) *Client {
+	t.Helper()
+	sn := &fakeDownloadSN{fileName: "report.pdf", content: content}
+	return &Client{
+		snClient: sn,
+		tasks:    NewTaskManager(sn),
+		config:   Config{SpoolDir: t.TempDir()},
+		actions: &fakeActions{action: &types.Action{
+			ID:          "42",
+			Type:        types.ActionTypeCascade,
+			Metadata:    &types.CascadeMetadata{DataHash: dataHash, FileName: "report.pdf"},
+			FileSizeKbs: 1,
+		}},
+	}
+}
+
+func blake3B64(data []byte) string {
+	sum := blake3.Sum256(data)
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+func TestDownloadVerifiesHash(t *testing.T) {
+	content := []byte("cascade payload")
+	c := newDownloadClient(t, content, blake3B64(content))
+	outDir := t.TempDir()
+
+	var stages []ProgressStage
+	var last Progress
+	res, err := c.Download(context.Background(), "42", outDir, WithDownloadProgress(func(p Progress) {
+		stages = append(stages, p.Stage)
+		last = p
+	}))
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(outDir, "42", "report.pdf"), res.OutputPath)
+	require.Equal(t, ProgressDownloading, stages[0])
+	require.Equal(t, ProgressCompleted, last.Stage)
+	require.Equal(t, int64(len(content)), last.BytesDone)
+	require.Equal(t, int64(len(content)), last.BytesTotal)
+
+	var buf bytes.Buffer
+	res, err = c.DownloadTo(context.Background(), "42", &buf)
+	require.NoError(t, err)
+	require.Equal(t, "task-1", res.TaskID)
+	require.Equal(t, content, buf.Bytes())
+	require.Zero(t, c.spooler().used)
+}
+
+func TestDownloadIntegrityError(t *testing.T) {
+	c := newDownloadClient(t, []byte("tampered"), blake3B64([]byte("original")))
+
+	var buf bytes.Buffer
+	_, err := c.DownloadTo(context.Background(), "42", &buf)
+	require.ErrorIs(t, err, types.ErrIntegrity)
+	var ie *types.IntegrityError
+	require.True(t, errors.As(err, &ie))
+	require.Equal(t, "42", ie.ActionID)
+	require.Equal(t, blake3B64([]byte("tampered")), ie.Actual)
+	require.Zero(t, buf.Len())
+
+	c.actions = nil
+	_, err = c.Download(context.Background(), "42", t.TempDir())
+	require.ErrorContains(t, err, "SetBlockchain")
+}
@@ -58,6 +58,8 @@ const (
 	SDKGoDownloadStarted              EventType = "sdk-go:download_started"
 	SDKGoDownloadCompleted            EventType = "sdk-go:download_completed"
 	SDKGoDownloadSignatureGenerated   EventType = "sdk-go:download_signature_generated"
+	SDKGoBatchProgress                EventType = "sdk-go:batch_progress"
+	SDKGoBatchCompleted               EventType = "sdk-go:batch_completed"
 )
 
 // EventDataKey identifies metadata entries.
@@ -95,6 +97,11 @@ const (
 
 	KeyTaskID   EventDataKey = "task_id"
 	KeyActionID EventDataKey = "action_id"
+
+	KeySucceeded EventDataKey = "succeeded"
+	KeyFailed    EventDataKey = "failed"
+	KeySkipped   EventDataKey = "skipped"
+	KeySpent     EventDataKey = "spent"
 )
 
 // Event represents an emitted task event.
@@ -0,0 +1,208 @@
+package cascade
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/LumeraProtocol/sdk-go/types"
+)
+
+// JournalStage is the last completed step of a journaled upload.
+type JournalStage string
+
+const (
+	// JournalStageMetadataBuilt means the request message was built but the
+	// action may not be registered yet.
+	JournalStageMetadataBuilt JournalStage = "metadata_built"
+	// JournalStageActionRegistered means the action fee was paid and ActionID is known.
+	JournalStageActionRegistered JournalStage = "action_registered"
+	// JournalStageTaskStarted means the supernode upload task was started.
+	JournalStageTaskStarted JournalStage = "task_started"
+	// JournalStageCompleted means the upload finished.
+	JournalStageCompleted JournalStage = "completed"
+)
+
+// JournalEntry records the progress of one upload. It holds everything needed
+// to continue the upload without building new metadata.
+type JournalEntry struct {
+	ID       string       `json:"id"`
+	Stage    JournalStage `json:"stage"`
+	FilePath string       `json:"file_path"`
+	Memo     string       `json:"memo,omitempty"`
+	// Spooled marks FilePath as a temporary copy made by UploadReader; it is
+	// removed once the upload completes.
+	Spooled bool `json:"spooled,omitempty"`
+
+	// Request message fields.
+	Creator     string `json:"creator"`
+	Metadata    string `json:"metadata"`
+	Price       string `json:"price"`
+	Expiration  string `json:"expiration"`
+	FileSizeKbs string `json:"file_size_kbs,omitempty"`
+	AppPubkey   []byte `json:"app_pubkey,omitempty"`
+	// Signer is the ICA creator address used for supernode signatures, if any.
+	Signer string `json:"signer,omitempty"`
+
+	ActionID string `json:"action_id,omitempty"`
+	TxHash   string `json:"tx_hash,omitempty"`
+	Height   int64  `json:"height,omitempty"`
+	TaskID   string `json:"task_id,omitempty"`
+
+	// LastError is the error that interrupted the upload, if any.
+	LastError string    `json:"last_error,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Journal persists upload progress as one JSON file per upload in a
+// directory. Entries are replaced atomically, so a crash leaves either the
+// previous or the new stage on disk.
+type Journal struct {
+	dir string
+	mu  sync.Mutex
+}
+
+// NewJournal opens (creating if needed) a journal stored in dir.
+func NewJournal(dir string) (*Journal, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("journal dir is required")
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("create journal dir: %w", err)
+	}
+	return &Journal{dir: dir}, nil
+}
+
+// Get returns the entry with the given upload ID; missing entries wrap
+// types.ErrNotFound.
+func (j *Journal) Get(id string) (*JournalEntry, error) {
+	if err := checkJournalID(id); err != nil {
+		return nil, err
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.read(j.path(id))
+}
+
+// List returns every entry, oldest first.
+func (j *Journal) List() ([]*JournalEntry, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	files, err := filepath.Glob(filepath.Join(j.dir, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("list journal: %w", err)
+	}
+	out := make([]*JournalEntry, 0, len(files))
+	for _, f := range files {
+		e, err := j.read(f)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, e)
+	}
+	sort.Slice(out, func(a, b int) bool { return out[a].CreatedAt.Before(out[b].CreatedAt) })
+	return out, nil
+}
+
+// Pending returns the entries that have not completed, oldest first.
+func (j *Journal) Pending() ([]*JournalEntry, error) {
+	all, err := j.List()
+	if err != nil {
+		return nil, err
+	}
+	out := all[:0]
+	for _, e := range all {
+		if e.Stage != JournalStageCompleted {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+// Remove deletes an entry. Removing a missing entry is not an error.
+func (j *Journal) Remove(id string) error {
+	if err := checkJournalID(id); err != nil {
+		return err
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if err := os.Remove(j.path(id)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("remove journal entry: %w", err)
+	}
+	return nil
+}
+
+// save writes e, updating its timestamps.
+func (j *Journal) save(e *JournalEntry) error {
+	now := time.Now().UTC()
+	if e.CreatedAt.IsZero() {
+		e.CreatedAt = now
+	}
+	e.UpdatedAt = now
+	data, err := json.MarshalIndent(e, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode journal entry: %w", err)
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	tmp, err := os.CreateTemp(j.dir, e.ID+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("write journal entry: %w", err)
+	}
+	defer os.Remove(tmp.Name()) //nolint:errcheck
+	if _, err := tmp.Write(data); err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("write journal entry: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), j.path(e.ID)); err != nil {
+		return fmt.Errorf("write journal entry: %w", err)
+	}
+	return nil
+}
+
+func (j *Journal) read(path string) (*JournalEntry, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("journal entry %s: %w", strings.TrimSuffix(filepath.Base(path), ".json"), types.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read journal entry: %w", err)
+	}
+	var e JournalEntry
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, fmt.Errorf("decode journal entry %s: %w", path, err)
+	}
+	return &e, nil
+}
+
+func (j *Journal) path(id string) string {
+	return filepath.Join(j.dir, id+".json")
+}
+
+func checkJournalID(id string) error {
+	if id == "" || strings.ContainsAny(id, `/\`) || id == "." || id == ".." {
+		return fmt.Errorf("invalid upload id %q", id)
+	}
+	return nil
+}
+
+func newUploadID() string {
+	var b [8]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
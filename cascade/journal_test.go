@@ -0,0 +1,237 @@
+package cascade
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	actiontypes "github.com/LumeraProtocol/lumera/x/action/v1/types"
+	"github.com/LumeraProtocol/supernode/v2/sdk/task"
+	"github.com/stretchr/testify/require"
+
+	"github.com/LumeraProtocol/sdk-go/types"
+)
+
+// fakeUploadSN fails StartCascade while startErr is set.
+type fakeUploadSN struct {
+	fakeSNClient
+	startErr error
+	started  int
+}
+
+func (f *fakeUploadSN) StartCascade(_ context.Context, _ string, _ string, _ string) (string, error) {
+	if f.startErr != nil {
+		return "", f.startErr
+	}
+	f.started++
+	return "task-7", nil
+}
+
+func (f *fakeUploadSN) GetTask(_ context.Context, taskID string) (*task.TaskEntry, bool) {
+	return &task.TaskEntry{TaskID: taskID, Status: task.StatusCompleted}, true
+}
+
+func TestJournalStore(t *testing.T) {
+	j, err := NewJournal(t.TempDir())
+	require.NoError(t, err)
+
+	_, err = j.Get("missing")
+	require.ErrorIs(t, err, types.ErrNotFound)
+	_, err = j.Get("../x")
+	require.Error(t, err)
+
+	require.NoError(t, j.save(&JournalEntry{ID: "a", Stage: JournalStageActionRegistered, ActionID: "9"}))
+	require.NoError(t, j.save(&JournalEntry{ID: "b", Stage: JournalStageCompleted}))
+	e, err := j.Get("a")
+	require.NoError(t, err)
+	require.Equal(t, "9", e.ActionID)
+	require.False(t, e.CreatedAt.IsZero())
+
+	all, err := j.List()
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	pending, err := j.Pending()
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	require.Equal(t, "a", pending[0].ID)
+
+	require.NoError(t, j.Remove("a"))
+	require.NoError(t, j.Remove("a"))
+	all, err = j.List()
+	require.NoError(t, err)
+	require.Len(t, all, 1)
+}
+
+func TestResumeAfterRegistration(t *testing.T) {
+	sn := &fakeUploadSN{
+		fakeSNClient: fakeSNClient{meta: actiontypes.CascadeMetadata{DataHash: "h", FileName: "f.bin", Signatures: "sig"}, price: "10ulume", expiration: "99999999999"},
+		startErr:     errors.New("supernodes unreachable"),
+	}
+	c := &Client{snClient: sn, tasks: NewTaskManager(sn), actions: &fakeActions{action: &types.Action{ID: "55", State: types.ActionStatePending}}}
+	j, err := NewJournal(t.TempDir())
+	require.NoError(t, err)
+	filePath := filepath.Join(t.TempDir(), "f.bin")
+	require.NoError(t, os.WriteFile(filePath, []byte("data"), 0o600))
+
+	registrations := 0
+	send := func(context.Context, *actiontypes.MsgRequestAction, []byte, string, *UploadOptions) (*types.ActionResult, error) {
+		registrations++
+		return &types.ActionResult{ActionID: "55", TxHash: "ABC", Height: 10}, nil
+	}
+
+	_, err = c.Upload(context.Background(), "creator", nil, filePath, WithJournal(j), WithID("up-1"), WithICASendFunc(send))
+	require.ErrorContains(t, err, "supernodes unreachable")
+	e, err := j.Get("up-1")
+	require.NoError(t, err)
+	require.Equal(t, JournalStageActionRegistered, e.Stage)
+	require.Equal(t, "55", e.ActionID)
+	require.Contains(t, e.LastError, "supernodes unreachable")
+
+	_, err = c.Upload(context.Background(), "creator", nil, filePath, WithJournal(j), WithID("up-1"), WithICASendFunc(send))
+	require.ErrorContains(t, err, "use Resume")
+
+	sn.startErr = nil
+	res, err := c.Resume(context.Background(), nil, j, "up-1", WithICASendFunc(send))
+	require.NoError(t, err)
+	require.Equal(t, 1, registrations)
+	require.Equal(t, 1, sn.started)
+	require.Equal(t, "55", res.ActionID)
+	require.Equal(t, "ABC", res.TxHash)
+	require.Equal(t, "task-7", res.TaskID)
+	e, err = j.Get("up-1")
+	require.NoError(t, err)
+	require.Equal(t, JournalStageCompleted, e.Stage)
+	require.Empty(t, e.LastError)
+}
+
+func TestResumeFindsRegisteredAction(t *testing.T) {
+	sn := &fakeUploadSN{}
+	actions := &fakeActions{found: []*types.Action{
+		{ID: "1", Creator: "creator", Metadata: &types.CascadeMetadata{DataHash: "h", Signatures: "other"}},
+		{ID: "2", Creator: "creator", Metadata: &types.CascadeMetadata{DataHash: "h", Signatures: "sig"}, BlockHeight: 12},
+	}}
+	c := &Client{snClient: sn, tasks: NewTaskManager(sn), actions: actions}
+	j, err := NewJournal(t.TempDir())
+	require.NoError(t, err)
+	filePath := filepath.Join(t.TempDir(), "f.bin")
+	require.NoError(t, os.WriteFile(filePath, []byte("data"), 0o600))
+	require.NoError(t, j.save(&JournalEntry{
+		ID:       "up-2",
+		Stage:    JournalStageMetadataBuilt,
+		FilePath: filePath,
+		Creator:  "creator",
+		Metadata: `{"data_hash":"h","signatures":"sig"}`,
+	}))
+
+	// No blockchain client and no send func: registering again would fail.
+	res, err := c.Resume(context.Background(), nil, j, "up-2")
+	require.NoError(t, err)
+	require.Equal(t, "2", res.ActionID)
+	require.Equal(t, int64(12), res.Height)
+	require.Equal(t, 1, sn.started)
+
+	pending, err := j.Pending()
+	require.NoError(t, err)
+	require.Empty(t, pending)
+}
+
+// fakeTxs serves RequestActionTxResult from a map of tx hash to result or error.
+type fakeTxs map[string]any
+
+func (f fakeTxs) RequestActionTxResult(_ context.Context, txHash string) (*types.ActionResult, error) {
+	switch v := f[txHash].(type) {
+	case *types.ActionResult:
+		return v, nil
+	case error:
+		return nil, v
+	}
+	return nil, types.ErrNotFound
+}
+
+func TestResumeRegistrationLookup(t *testing.T) {
+	// Earlier uploads of the same file fill more than one lookup page.
+	var many []*types.Action
+	for i := 0; i < 2*resumeLookupPageSize+10; i++ {
+		many = append(many, &types.Action{ID: strconv.Itoa(i), Creator: "creator", Metadata: &types.CascadeMetadata{DataHash: "h", Signatures: "old"}})
+	}
+	many[resumeLookupPageSize+20] = &types.Action{ID: "ours", Creator: "creator", Metadata: &types.CascadeMetadata{DataHash: "h", Signatures: "sig"}, BlockHeight: 30}
+
+	tests := []struct {
+		name    string
+		txs     fakeTxs
+		wantID  string
+		queries int
+	}{
+		{name: "journaled tx", txs: fakeTxs{"TX1": &types.ActionResult{ActionID: "77", TxHash: "TX1", Height: 40}}, wantID: "77"},
+		{name: "tx not found", txs: fakeTxs{}, wantID: "ours", queries: 2},
+		{name: "tx failed", txs: fakeTxs{"TX1": fmt.Errorf("tx TX1: %w with code 5", types.ErrTxFailed)}, wantID: "ours", queries: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sn := &fakeUploadSN{}
+			actions := &fakeActions{found: many}
+			c := &Client{snClient: sn, tasks: NewTaskManager(sn), actions: actions, txs: tt.txs}
+			j, err := NewJournal(t.TempDir())
+			require.NoError(t, err)
+			filePath := filepath.Join(t.TempDir(), "f.bin")
+			require.NoError(t, os.WriteFile(filePath, []byte("data"), 0o600))
+			require.NoError(t, j.save(&JournalEntry{
+				ID:       "up-3",
+				Stage:    JournalStageMetadataBuilt,
+				FilePath: filePath,
+				Creator:  "creator",
+				Metadata: `{"data_hash":"h","signatures":"sig"}`,
+				TxHash:   "TX1",
+			}))
+
+			// No blockchain client and no send func: registering again would fail.
+			res, err := c.Resume(context.Background(), nil, j, "up-3")
+			require.NoError(t, err)
+			require.Equal(t, tt.wantID, res.ActionID)
+			require.Equal(t, tt.queries, actions.queries)
+			require.Equal(t, 1, sn.started)
+		})
+	}
+
+	// Not on chain at all: the lookup pages through to the end.
+	actions := &fakeActions{found: many[:2*resumeLookupPageSize]}
+	_, err := findRegisteredAction(context.Background(), actions, &JournalEntry{Creator: "creator", Metadata: `{"data_hash":"h","signatures":"none"}`})
+	require.NoError(t, err)
+	require.Equal(t, 3, actions.queries)
+}
+
+func TestUploadReaderJournalSpoolCleanup(t *testing.T) {
+	sn := &fakeUploadSN{
+		fakeSNClient: fakeSNClient{meta: actiontypes.CascadeMetadata{DataHash: blake3B64([]byte("data")), FileName: "f.bin", Signatures: "sig"}, price: "10ulume", expiration: "99999999999"},
+		startErr:     errors.New("supernodes unreachable"),
+	}
+	spoolDir := t.TempDir()
+	c := &Client{snClient: sn, tasks: NewTaskManager(sn), config: Config{SpoolDir: spoolDir}}
+	j, err := NewJournal(t.TempDir())
+	require.NoError(t, err)
+	send := func(context.Context, *actiontypes.MsgRequestAction, []byte, string, *UploadOptions) (*types.ActionResult, error) {
+		return &types.ActionResult{ActionID: "55"}, nil
+	}
+	spooled := func() int {
+		dirs, err := os.ReadDir(spoolDir)
+		require.NoError(t, err)
+		return len(dirs)
+	}
+
+	// A failure after journaling keeps the data for Resume.
+	_, err = c.UploadBytes(context.Background(), "creator", nil, "f.bin", []byte("data"), WithJournal(j), WithID("up-3"), WithICASendFunc(send))
+	require.ErrorContains(t, err, "supernodes unreachable")
+	require.Equal(t, 1, spooled())
+
+	// A failure before journaling leaves nothing behind.
+	_, err = c.UploadBytes(context.Background(), "creator", nil, "f.bin", []byte("data"), WithJournal(j), WithID("up-3"), WithICASendFunc(send))
+	require.ErrorContains(t, err, "use Resume")
+	require.Equal(t, 1, spooled())
+	_, err = c.UploadBytes(context.Background(), "creator", nil, "g.bin", []byte("other"), WithJournal(j), WithICASendFunc(send))
+	require.ErrorContains(t, err, "does not match spooled data")
+	require.Equal(t, 1, spooled())
+}
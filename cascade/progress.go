@@ -0,0 +1,175 @@
+package cascade
+
+import (
+	"time"
+
+	snevent "github.com/LumeraProtocol/supernode/v2/sdk/event"
+	"github.com/LumeraProtocol/supernode/v2/sdk/task"
+
+	sdkEvent "github.com/LumeraProtocol/sdk-go/cascade/event"
+)
+
+// ProgressStage is the phase of an upload or download reported to a
+// progress callback.
+type ProgressStage string
+
+const (
+	ProgressPreparing   ProgressStage = "preparing"   // hashing the file and building metadata
+	ProgressRegistering ProgressStage = "registering" // request action tx in flight
+	ProgressUploading   ProgressStage = "uploading"   // streaming the file to a supernode
+	ProgressProcessing  ProgressStage = "processing"  // supernodes encoding and storing the data
+	ProgressDownloading ProgressStage = "downloading" // receiving the file from a supernode
+	ProgressVerifying   ProgressStage = "verifying"   // checking the data hash
+	ProgressCompleted   ProgressStage = "completed"
+)
+
+// Progress describes the state of a single upload or download.
+type Progress struct {
+	Stage    ProgressStage
+	ActionID string
+	TaskID   string
+	// BytesTotal is 0 while unknown. Downloads estimate it from the action's
+	// file size until the transfer completes.
+	BytesDone  int64
+	BytesTotal int64
+	// ThroughputMBS is the transfer rate in MiB/s, 0 while unknown.
+	ThroughputMBS float64
+	// ETA estimates the remaining transfer time, 0 while unknown.
+	ETA time.Duration
+	// Event is the SDK or supernode event behind this report, if any.
+	Event   sdkEvent.EventType
+	Message string
+}
+
+// Percent returns the transferred share of BytesTotal, or 0 when unknown.
+func (p Progress) Percent() float64 {
+	if p.BytesTotal <= 0 {
+		return 0
+	}
+	return float64(p.BytesDone) / float64(p.BytesTotal) * 100
+}
+
+// progressTracker turns task events and byte counts into Progress reports
+// for one operation. A nil tracker ignores every call.
+type progressTracker struct {
+	fn    func(Progress)
+	p     Progress
+	start time.Time // first transferred byte
+	seen  int       // task events already consumed
+}
+
+func newProgressTracker(fn func(Progress)) *progressTracker {
+	if fn == nil {
+		return nil
+	}
+	return &progressTracker{fn: fn}
+}
+
+func (t *progressTracker) stage(s ProgressStage, msg string) {
+	if t == nil {
+		return
+	}
+	t.p.Stage = s
+	t.p.Event = ""
+	t.p.Message = msg
+	t.fn(t.p)
+}
+
+func (t *progressTracker) setIDs(actionID, taskID string) {
+	if t == nil {
+		return
+	}
+	if actionID != "" {
+		t.p.ActionID = actionID
+	}
+	if taskID != "" {
+		t.p.TaskID = taskID
+	}
+}
+
+// bytes reports a transfer position, deriving throughput and ETA from the
+// time since the first byte.
+func (t *progressTracker) bytes(done, total int64) {
+	if t == nil || (done == t.p.BytesDone && total == t.p.BytesTotal) {
+		return
+	}
+	now := time.Now()
+	if t.start.IsZero() && done > 0 {
+		t.start = now
+	}
+	t.p.BytesDone = done
+	if total > 0 {
+		t.p.BytesTotal = total
+	}
+	t.p.Event = ""
+	t.p.Message = ""
+	if elapsed := now.Sub(t.start).Seconds(); !t.start.IsZero() && elapsed > 0 {
+		t.p.ThroughputMBS = float64(done) / (1024 * 1024) / elapsed
+		t.p.ETA = 0
+		if rate := float64(done) / elapsed; rate > 0 && t.p.BytesTotal > done {
+			t.p.ETA = time.Duration(float64(t.p.BytesTotal-done) / rate * float64(time.Second))
+		}
+	}
+	t.fn(t.p)
+}
+
+// task consumes the events recorded for the operation's task since the last call.
+func (t *progressTracker) task(entry *task.TaskEntry) {
+	if t == nil || entry == nil {
+		return
+	}
+	for ; t.seen < len(entry.Events); t.seen++ {
+		t.event(entry.Events[t.seen])
+	}
+}
+
+func (t *progressTracker) event(e snevent.Event) {
+	switch e.Type {
+	case snevent.SDKUploadStarted:
+		t.p.Stage = ProgressUploading
+		t.p.BytesDone = 0
+		t.p.BytesTotal = int64Value(e.Data[snevent.KeyBytesTotal], t.p.BytesTotal)
+		t.start = time.Now()
+	case snevent.SDKUploadCompleted, snevent.SDKDownloadCompleted:
+		total := int64Value(e.Data[snevent.KeyBytesTotal], t.p.BytesTotal)
+		t.p.BytesDone, t.p.BytesTotal = total, total
+		t.p.ThroughputMBS = floatValue(e.Data[snevent.KeyThroughputMBS], t.p.ThroughputMBS)
+		t.p.ETA = 0
+		if e.Type == snevent.SDKUploadCompleted {
+			t.p.Stage = ProgressProcessing
+		}
+	case snevent.SDKDownloadStarted:
+		t.p.Stage = ProgressDownloading
+	default:
+		if t.p.Stage == ProgressUploading && t.p.BytesDone > 0 {
+			t.p.Stage = ProgressProcessing
+		}
+	}
+	t.p.Event = sdkEvent.EventType(e.Type)
+	t.p.Message, _ = e.Data[snevent.KeyMessage].(string)
+	t.fn(t.p)
+}
+
+func int64Value(v any, def int64) int64 {
+	switch n := v.(type) {
+	case int64:
+		return n
+	case int:
+		return int64(n)
+	case float64:
+		return int64(n)
+	}
+	return def
+}
+
+func floatValue(v any, def float64) float64 {
+	switch n := v.(type) {
+	case float64:
+		return n
+	case int64:
+		return float64(n)
+	case int:
+		return float64(n)
+	}
+	return def
+}
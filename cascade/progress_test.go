@@ -0,0 +1,74 @@
+package cascade
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	snevent "github.com/LumeraProtocol/supernode/v2/sdk/event"
+	"github.com/LumeraProtocol/supernode/v2/sdk/task"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeProgressSN reports a finished upload task with transfer events.
+type fakeProgressSN struct {
+	fakeSNClient
+}
+
+func (f *fakeProgressSN) StartCascade(_ context.Context, _ string, _ string, _ string) (string, error) {
+	return "task-p", nil
+}
+
+func (f *fakeProgressSN) GetTask(_ context.Context, taskID string) (*task.TaskEntry, bool) {
+	return &task.TaskEntry{TaskID: taskID, Status: task.StatusCompleted, Events: []snevent.Event{
+		{Type: snevent.SDKUploadStarted, Data: snevent.EventData{snevent.KeyBytesTotal: int64(4)}},
+		{Type: snevent.SDKUploadCompleted, Data: snevent.EventData{snevent.KeyBytesTotal: int64(4), snevent.KeyThroughputMBS: 2.5}},
+		{Type: snevent.SupernodeActionFinalized, Data: snevent.EventData{snevent.KeyMessage: "finalized"}},
+	}}, true
+}
+
+func TestUploadToSupernodeProgress(t *testing.T) {
+	sn := &fakeProgressSN{}
+	c := &Client{snClient: sn, tasks: NewTaskManager(sn)}
+	filePath := filepath.Join(t.TempDir(), "f.bin")
+	require.NoError(t, os.WriteFile(filePath, []byte("data"), 0o600))
+
+	var got []Progress
+	taskID, err := c.UploadToSupernodeWithOptions(context.Background(), "77", filePath, WithProgress(func(p Progress) {
+		got = append(got, p)
+	}))
+	require.NoError(t, err)
+	require.Equal(t, "task-p", taskID)
+
+	stages := make([]ProgressStage, 0, len(got))
+	for _, p := range got {
+		stages = append(stages, p.Stage)
+		require.Equal(t, "77", p.ActionID)
+		require.Equal(t, "task-p", p.TaskID)
+	}
+	require.Equal(t, []ProgressStage{ProgressUploading, ProgressUploading, ProgressProcessing, ProgressProcessing, ProgressCompleted}, stages)
+	done := got[2]
+	require.Equal(t, int64(4), done.BytesDone)
+	require.Equal(t, int64(4), done.BytesTotal)
+	require.Equal(t, 100.0, done.Percent())
+	require.Equal(t, 2.5, done.ThroughputMBS)
+	require.Equal(t, "finalized", got[3].Message)
+}
+
+func TestProgressTrackerETA(t *testing.T) {
+	var last Progress
+	tr := newProgressTracker(func(p Progress) { last = p })
+	tr.bytes(1, 100)
+	tr.start = time.Now().Add(-time.Second)
+	tr.bytes(50, 100)
+	require.Equal(t, 50.0, last.Percent())
+	require.InDelta(t, float64(time.Second), float64(last.ETA), float64(100*time.Millisecond))
+	require.Greater(t, last.ThroughputMBS, 0.0)
+
+	var nilTracker *progressTracker
+	nilTracker.stage(ProgressCompleted, "")
+	nilTracker.bytes(1, 2)
+	nilTracker.task(&task.TaskEntry{})
+}
@@ -0,0 +1,195 @@
+package cascade
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	actiontypes "github.com/LumeraProtocol/lumera/x/action/v1/types"
+
+	"github.com/LumeraProtocol/sdk-go/blockchain"
+	"github.com/LumeraProtocol/sdk-go/types"
+)
+
+// resumeLookupPageSize is the page size used when searching for an action
+// registered by an interrupted upload.
+const resumeLookupPageSize = 100
+
+// Resume continues the journaled upload id from its last recorded stage.
+// An action registered before the interruption is never registered again:
+// for uploads interrupted around the registration tx, the journaled tx is
+// looked up and the chain is searched for an action with the journaled
+// metadata first. Uploads sent through an
+// interchain account need WithICASendFunc in opts.
+func (c *Client) Resume(ctx context.Context, bc *blockchain.Client, j *Journal, id string, opts ...UploadOption) (*types.CascadeResult, error) {
+	if j == nil {
+		return nil, fmt.Errorf("journal is required")
+	}
+	entry, err := j.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	options := newUploadOptions(opts)
+	options.Journal = j
+	options.ID = entry.ID
+	if entry.Stage == JournalStageCompleted {
+		return c.continueUpload(ctx, bc, entry, nil, nil, options)
+	}
+	if _, err := os.Stat(entry.FilePath); err != nil {
+		return nil, fmt.Errorf("resume %s: upload data: %w", entry.ID, err)
+	}
+
+	actions := c.actionQuerier(bc)
+	if actions == nil {
+		return nil, fmt.Errorf("resume %s: a blockchain client is required to check the action", entry.ID)
+	}
+
+	var msg *actiontypes.MsgRequestAction
+	switch entry.Stage {
+	case JournalStageMetadataBuilt:
+		found, err := c.findRegistration(ctx, bc, actions, entry)
+		if err != nil {
+			return nil, fmt.Errorf("resume %s: %w", entry.ID, err)
+		}
+		if found {
+			c.logf("cascade: resume %s found registered action %s", entry.ID, entry.ActionID)
+			if err := c.markRegistered(entry, options); err != nil {
+				return nil, err
+			}
+			break
+		}
+		if exp, err := strconv.ParseInt(entry.Expiration, 10, 64); err == nil && time.Unix(exp, 0).Before(time.Now()) {
+			return nil, fmt.Errorf("resume %s: request expired before registration; remove the entry and upload again", entry.ID)
+		}
+		if options.ICASendFunc == nil && len(entry.AppPubkey) > 0 {
+			return nil, fmt.Errorf("resume %s: ica upload requires WithICASendFunc", entry.ID)
+		}
+		options.ICACreatorAddress = entry.Signer
+		options.AppPubkey = entry.AppPubkey
+		msg = &actiontypes.MsgRequestAction{
+			Creator:        entry.Creator,
+			ActionType:     actiontypes.ActionTypeCascade.String(),
+			Metadata:       entry.Metadata,
+			Price:          entry.Price,
+			ExpirationTime: entry.Expiration,
+			FileSizeKbs:    entry.FileSizeKbs,
+			AppPubkey:      entry.AppPubkey,
+		}
+	case JournalStageActionRegistered, JournalStageTaskStarted:
+		action, err := actions.GetAction(ctx, entry.ActionID)
+		if err != nil {
+			return nil, fmt.Errorf("resume %s: get action %s: %w", entry.ID, entry.ActionID, err)
+		}
+		switch action.State {
+		case types.ActionStateDone, types.ActionStateApproved:
+			// The supernodes finished before the interruption was noticed.
+			entry.Stage = JournalStageCompleted
+			if err := c.journal(j, entry); err != nil {
+				return nil, err
+			}
+		case types.ActionStateRejected, types.ActionStateFailed, types.ActionStateExpired:
+			return nil, fmt.Errorf("resume %s: action %s is %s", entry.ID, entry.ActionID, action.State)
+		}
+	default:
+		return nil, fmt.Errorf("resume %s: unknown stage %q", entry.ID, entry.Stage)
+	}
+	return c.continueUpload(ctx, bc, entry, msg, []byte(entry.Metadata), options)
+}
+
+// ResumeAll resumes every pending entry of j in creation order. It returns
+// the finished uploads and the errors of the others.
+func (c *Client) ResumeAll(ctx context.Context, bc *blockchain.Client, j *Journal, opts ...UploadOption) ([]*types.CascadeResult, error) {
+	if j == nil {
+		return nil, fmt.Errorf("journal is required")
+	}
+	pending, err := j.Pending()
+	if err != nil {
+		return nil, err
+	}
+	var out []*types.CascadeResult
+	var errs []error
+	for _, e := range pending {
+		res, err := c.Resume(ctx, bc, j, e.ID, opts...)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		out = append(out, res)
+	}
+	return out, errors.Join(errs...)
+}
+
+// actionQuerier returns the action queries of bc, falling back to the client's own.
+func (c *Client) actionQuerier(bc *blockchain.Client) actionQuerier {
+	if bc != nil && bc.Action != nil {
+		return bc.Action
+	}
+	return c.actions
+}
+
+// txQuerier returns the tx lookups of bc, falling back to the client's own.
+func (c *Client) txQuerier(bc *blockchain.Client) registrationTxQuerier {
+	if bc != nil {
+		return bc
+	}
+	return c.txs
+}
+
+// findRegistration reports whether an interrupted registration of entry
+// created its action, filling in ActionID and Height when it did. The
+// journaled tx is checked first, then the chain is searched by metadata.
+// A tx still in the mempool is found by neither; sending the registration
+// again is then rejected for reusing its account sequence.
+func (c *Client) findRegistration(ctx context.Context, bc *blockchain.Client, actions actionQuerier, entry *JournalEntry) (bool, error) {
+	if txs := c.txQuerier(bc); txs != nil && entry.TxHash != "" {
+		ar, err := txs.RequestActionTxResult(ctx, entry.TxHash)
+		switch {
+		case err == nil:
+			entry.ActionID, entry.Height = ar.ActionID, ar.Height
+			return true, nil
+		case errors.Is(err, types.ErrTxFailed):
+			c.logf("cascade: registration tx of %s failed: %v", entry.ID, err)
+			entry.TxHash = ""
+		case !errors.Is(err, types.ErrNotFound):
+			return false, fmt.Errorf("check registration tx: %w", err)
+		}
+	}
+	action, err := findRegisteredAction(ctx, actions, entry)
+	if err != nil || action == nil {
+		return false, err
+	}
+	entry.ActionID, entry.Height = action.ID, action.BlockHeight
+	return true, nil
+}
+
+// findRegisteredAction looks for the action an interrupted upload may have
+// registered. Metadata signatures are unique per build, so a cascade action
+// from the same creator with the same data hash and signatures is ours. All
+// actions with the data hash are scanned, since one file may be uploaded
+// many times.
+func findRegisteredAction(ctx context.Context, actions actionQuerier, entry *JournalEntry) (*types.Action, error) {
+	var meta actiontypes.CascadeMetadata
+	if err := json.Unmarshal([]byte(entry.Metadata), &meta); err != nil {
+		return nil, fmt.Errorf("decode journaled metadata: %w", err)
+	}
+	query := blockchain.MetadataFieldDataHash + "=" + meta.DataHash
+	for offset := uint64(0); ; offset += resumeLookupPageSize {
+		found, err := actions.QueryActionByMetadataEnum(ctx, actiontypes.ActionTypeCascade, query, resumeLookupPageSize, offset)
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range found {
+			cm, ok := a.Metadata.(*types.CascadeMetadata)
+			if ok && a.Creator == entry.Creator && cm.Signatures == meta.Signatures {
+				return a, nil
+			}
+		}
+		if len(found) < resumeLookupPageSize {
+			return nil, nil
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package cascade
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"lukechampine.com/blake3"
+
+	"github.com/LumeraProtocol/sdk-go/types"
+)
+
+// spooler stages in-memory and streamed uploads on disk, since the supernode
+// SDK only works from file paths. It enforces a budget on the bytes held by
+// all spooled files of a client at once.
+type spooler struct {
+	dir string
+	max int64 // 0 means unlimited
+
+	mu   sync.Mutex
+	used int64
+}
+
+func newSpooler(dir string, max int64) *spooler {
+	return &spooler{dir: dir, max: max}
+}
+
+func (s *spooler) reserve(n int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.max > 0 && s.used+n > s.max {
+		return fmt.Errorf("need %d bytes, %d of %d in use: %w", n, s.used, s.max, types.ErrSpoolLimit)
+	}
+	s.used += n
+	return nil
+}
+
+func (s *spooler) release(n int64) {
+	s.mu.Lock()
+	s.used -= n
+	s.mu.Unlock()
+}
+
+// spooledFile is a temporary copy of an upload. cleanup removes it and
+// returns its bytes to the budget; release only returns the bytes.
+type spooledFile struct {
+	path     string
+	size     int64
+	dataHash string // base64 blake3, as in CascadeMetadata.DataHash
+	cleanup  func()
+	release  func()
+}
+
+// spool copies r into <dir>/<tmp>/<name> so the file keeps its name in the
+// action metadata. A non-negative size is reserved up front and must match
+// the bytes read; a negative size reserves space as data arrives.
+func (s *spooler) spool(name string, r io.Reader, size int64) (*spooledFile, error) {
+	if name == "" || name == "." || name == ".." || filepath.Base(name) != name {
+		return nil, fmt.Errorf("invalid file name %q", name)
+	}
+	if r == nil {
+		return nil, fmt.Errorf("reader is required")
+	}
+	var reserved int64
+	if size >= 0 {
+		if err := s.reserve(size); err != nil {
+			return nil, err
+		}
+		reserved = size
+	}
+	dir, err := os.MkdirTemp(s.dir, "lumera-upload-*")
+	if err != nil {
+		s.release(reserved)
+		return nil, fmt.Errorf("create spool dir: %w", err)
+	}
+	w := &spoolWriter{spooler: s, reserved: reserved, fixed: size >= 0}
+	var once sync.Once
+	release := func() { once.Do(func() { s.release(w.reserved) }) }
+	cleanup := func() {
+		_ = os.RemoveAll(dir)
+		release()
+	}
+
+	path := filepath.Join(dir, name)
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		cleanup()
+		return nil, fmt.Errorf("create spool file: %w", err)
+	}
+	h := blake3.New(32, nil)
+	w.w = io.MultiWriter(f, h)
+	n, err := io.Copy(w, r)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil && size >= 0 && n != size {
+		err = fmt.Errorf("read %d bytes, expected %d", n, size)
+	}
+	if err != nil {
+		cleanup()
+		return nil, fmt.Errorf("spool %s: %w", name, err)
+	}
+	return &spooledFile{
+		path:     path,
+		size:     n,
+		dataHash: base64.StdEncoding.EncodeToString(h.Sum(nil)),
+		cleanup:  cleanup,
+		release:  release,
+	}, nil
+}
+
+// spoolWriter keeps writes within the reserved budget.
+type spoolWriter struct {
+	*spooler
+	w        io.Writer
+	written  int64
+	reserved int64
+	fixed    bool
+}
+
+func (w *spoolWriter) Write(p []byte) (int, error) {
+	n := int64(len(p))
+	if w.written+n > w.reserved {
+		if w.fixed {
+			return 0, fmt.Errorf("reader exceeds declared size %d", w.reserved)
+		}
+		extra := w.written + n - w.reserved
+		if err := w.reserve(extra); err != nil {
+			return 0, err
+		}
+		w.reserved += extra
+	}
+	written, err := w.w.Write(p)
+	w.written += int64(written)
+	return written, err
+}
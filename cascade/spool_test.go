@@ -0,0 +1,73 @@
+package cascade
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	actiontypes "github.com/LumeraProtocol/lumera/x/action/v1/types"
+	"github.com/stretchr/testify/require"
+	"lukechampine.com/blake3"
+
+	"github.com/LumeraProtocol/sdk-go/types"
+)
+
+func TestSpoolBudget(t *testing.T) {
+	s := newSpooler(t.TempDir(), 10)
+
+	f, err := s.spool("a.txt", strings.NewReader("hello"), 5)
+	require.NoError(t, err)
+	require.Equal(t, "a.txt", filepath.Base(f.path))
+	data, err := os.ReadFile(f.path)
+	require.NoError(t, err)
+	require.Equal(t, "hello", string(data))
+	sum := blake3.Sum256([]byte("hello"))
+	require.Equal(t, base64.StdEncoding.EncodeToString(sum[:]), f.dataHash)
+
+	// Only 5 bytes left while a.txt is held; unknown sizes are charged as they stream.
+	_, err = s.spool("b.txt", strings.NewReader("too long"), -1)
+	require.ErrorIs(t, err, types.ErrSpoolLimit)
+	_, err = s.spool("c.txt", strings.NewReader("123456"), 6)
+	require.ErrorIs(t, err, types.ErrSpoolLimit)
+
+	f.cleanup()
+	_, err = os.Stat(f.path)
+	require.True(t, os.IsNotExist(err))
+	require.Zero(t, s.used)
+
+	g, err := s.spool("b.txt", strings.NewReader("too long"), -1)
+	require.NoError(t, err)
+	require.Equal(t, int64(8), g.size)
+	g.cleanup()
+	require.Zero(t, s.used)
+}
+
+func TestSpoolRejects(t *testing.T) {
+	s := newSpooler(t.TempDir(), 0)
+	_, err := s.spool("../x", strings.NewReader("x"), 1)
+	require.Error(t, err)
+	_, err = s.spool("x", strings.NewReader("short"), 10)
+	require.ErrorContains(t, err, "expected 10")
+	_, err = s.spool("x", strings.NewReader("longer"), 2)
+	require.ErrorContains(t, err, "declared size")
+	require.Zero(t, s.used)
+}
+
+func TestUploadBytesChecksHashAndCleansUp(t *testing.T) {
+	snClient := &fakeSNClient{meta: actiontypes.CascadeMetadata{DataHash: "other", FileName: "doc.txt"}}
+	c := &Client{snClient: snClient, config: Config{SpoolDir: t.TempDir()}}
+
+	_, err := c.UploadBytes(context.Background(), "creator", nil, "doc.txt", []byte("payload"))
+	require.ErrorContains(t, err, "does not match")
+	require.Equal(t, "doc.txt", filepath.Base(snClient.lastFile))
+	_, err = os.Stat(snClient.lastFile)
+	require.True(t, os.IsNotExist(err))
+
+	_, err = c.UploadReader(context.Background(), "creator", nil, "doc.txt", bytes.NewReader(nil), 0)
+	require.Error(t, err)
+	require.Zero(t, c.spooler().used)
+}
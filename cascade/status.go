@@ -0,0 +1,21 @@
+package cascade
+
+import (
+	"context"
+	"fmt"
+
+	pb "github.com/LumeraProtocol/supernode/v2/gen/supernode"
+)
+
+// GetSupernodeStatus queries the status endpoint of the supernode registered
+// under the given supernode account address.
+func (c *Client) GetSupernodeStatus(ctx context.Context, supernodeAddress string) (*pb.StatusResponse, error) {
+	status, err := c.snClient.GetSupernodeStatus(ctx, supernodeAddress)
+	if err != nil {
+		return nil, fmt.Errorf("supernode %s status: %w", supernodeAddress, err)
+	}
+	if status == nil {
+		return nil, fmt.Errorf("supernode %s status: empty response", supernodeAddress)
+	}
+	return status, nil
+}
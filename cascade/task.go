@@ -2,62 +2,319 @@ package cascade
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	snsdk "github.com/LumeraProtocol/supernode/v2/sdk/action"
+	snevent "github.com/LumeraProtocol/supernode/v2/sdk/event"
+	"github.com/LumeraProtocol/supernode/v2/sdk/task"
+
+	sdkEvent "github.com/LumeraProtocol/sdk-go/cascade/event"
+	"github.com/LumeraProtocol/sdk-go/types"
+)
+
+const (
+	defaultTaskPollInterval    = 5 * time.Second
+	defaultTaskNotFoundTimeout = 30 * time.Second
 )
 
-// TaskManager manages task lifecycle
+// TaskManager manages task lifecycle. Waits complete on the task's events
+// from the SuperNode SDK and fall back to polling GetTask.
 type TaskManager struct {
-	client snsdk.Client
+	client          snsdk.Client
+	timeout         time.Duration
+	pollInterval    time.Duration
+	notFoundTimeout time.Duration
+
+	subOnce sync.Once
+	mu      sync.Mutex
+	watches map[string][]chan snevent.Event
+}
+
+// TaskManagerOption configures a TaskManager.
+type TaskManagerOption func(*TaskManager)
+
+// WithTaskTimeout bounds how long Wait blocks on a single task (0 => no limit).
+func WithTaskTimeout(d time.Duration) TaskManagerOption {
+	return func(tm *TaskManager) {
+		tm.timeout = d
+	}
+}
+
+// WithPollInterval sets how often Wait polls GetTask between events. Default is 5s.
+func WithPollInterval(d time.Duration) TaskManagerOption {
+	return func(tm *TaskManager) {
+		if d > 0 {
+			tm.pollInterval = d
+		}
+	}
+}
+
+// WithNotFoundTimeout sets how long Wait tolerates an unknown task ID before
+// failing with types.ErrNotFound. Default is 30s; 0 waits until the timeout.
+func WithNotFoundTimeout(d time.Duration) TaskManagerOption {
+	return func(tm *TaskManager) {
+		tm.notFoundTimeout = d
+	}
 }
 
 // NewTaskManager creates a new task manager
-func NewTaskManager(client snsdk.Client) *TaskManager {
-	return &TaskManager{
-		client: client,
+func NewTaskManager(client snsdk.Client, opts ...TaskManagerOption) *TaskManager {
+	tm := &TaskManager{
+		client:          client,
+		pollInterval:    defaultTaskPollInterval,
+		notFoundTimeout: defaultTaskNotFoundTimeout,
+		watches:         make(map[string][]chan snevent.Event),
+	}
+	for _, opt := range opts {
+		opt(tm)
 	}
+	return tm
 }
 
-// TaskInfo contains task information
+// TaskInfo is the status of a SuperNode SDK task.
 type TaskInfo struct {
-	TaskID string
+	TaskID   string
+	ActionID string
+	TaskType string
+	// Status is the SDK task status: PENDING, ACTIVE, COMPLETED or FAILED.
+	Status string
+	TxHash string
+	// Supernodes lists the supernodes the task tried, in order.
+	Supernodes []SupernodeAttempt
+	// Events is the task's event log.
+	Events []sdkEvent.Event
+	// Err is the cause reported for a failed task.
+	Err        error
+	StartedAt  time.Time
+	FinishedAt time.Time
+	Duration   time.Duration
+}
+
+// SupernodeAttempt records one supernode tried by a task.
+type SupernodeAttempt struct {
+	Address   string
+	Endpoint  string
+	Iteration int
+	Succeeded bool
+	Error     string
 }
 
-// Wait waits for a task to complete
+// TaskError reports a failed task with its full status. It matches
+// types.ErrTaskFailed and the task's cause with errors.Is.
+type TaskError struct {
+	Task *TaskInfo
+}
+
+func (e *TaskError) Error() string {
+	if e.Task.Err != nil {
+		return fmt.Sprintf("task %s failed: %v (tx: %s)", e.Task.TaskID, e.Task.Err, e.Task.TxHash)
+	}
+	return fmt.Sprintf("task %s failed (tx: %s)", e.Task.TaskID, e.Task.TxHash)
+}
+
+func (e *TaskError) Unwrap() []error {
+	if e.Task.Err != nil {
+		return []error{types.ErrTaskFailed, e.Task.Err}
+	}
+	return []error{types.ErrTaskFailed}
+}
+
+// Wait waits for a task to complete and returns its status. A failed task
+// returns the status together with a *TaskError; exceeding the configured
+// timeout returns the status so far with an error wrapping types.ErrTimeout.
 func (tm *TaskManager) Wait(ctx context.Context, taskID string) (*TaskInfo, error) {
-	ticker := time.NewTicker(1 * time.Second)
+	return tm.wait(ctx, taskID, nil)
+}
+
+// wait implements Wait; onTick, if set, sees the task entry (nil while not
+// found) on every poll and task event.
+func (tm *TaskManager) wait(ctx context.Context, taskID string, onTick func(*task.TaskEntry)) (*TaskInfo, error) {
+	start := time.Now()
+	events, unwatch := tm.watch(ctx, taskID)
+	defer unwatch()
+
+	var deadline <-chan time.Time
+	if tm.timeout > 0 {
+		timer := time.NewTimer(tm.timeout)
+		defer timer.Stop()
+		deadline = timer.C
+	}
+	ticker := time.NewTicker(tm.pollInterval)
 	defer ticker.Stop()
 
+	// seen holds events delivered by subscription, used when the SDK no
+	// longer has the task entry.
+	var seen []snevent.Event
+	var entry *task.TaskEntry
 	for {
+		current, found := tm.client.GetTask(ctx, taskID)
+		if onTick != nil {
+			onTick(current)
+		}
+		if found && current != nil {
+			entry = current
+			switch entry.Status {
+			case task.StatusCompleted:
+				return newTaskInfo(taskID, entry, seen, start), nil
+			case task.StatusFailed:
+				info := newTaskInfo(taskID, entry, seen, start)
+				return info, &TaskError{Task: info}
+			}
+		} else if status, ok := terminalStatus(seen); ok {
+			// The SDK dropped the entry; the events tell how the task ended.
+			info := newTaskInfo(taskID, entry, seen, start)
+			info.Status = string(status)
+			if status == task.StatusFailed {
+				return info, &TaskError{Task: info}
+			}
+			return info, nil
+		} else if entry == nil && tm.notFoundTimeout > 0 && time.Since(start) >= tm.notFoundTimeout {
+			return nil, fmt.Errorf("task %s: %w", taskID, types.ErrNotFound)
+		}
+
 		select {
 		case <-ctx.Done():
 			return nil, ctx.Err()
+		case <-deadline:
+			return newTaskInfo(taskID, entry, seen, start), fmt.Errorf("task %s: %w after %s", taskID, types.ErrTimeout, tm.timeout)
 		case <-ticker.C:
-			entry, found := tm.client.GetTask(ctx, taskID)
-			if !found || entry == nil {
-				// Task isn't found yet; continue polling until ctx timeout
-				continue
+		case e := <-events:
+			seen = append(seen, e)
+		}
+	}
+}
+
+// watch routes the task's events to the returned channel until unwatch is
+// called. The SDK cannot drop subscriptions, so a single one is shared.
+func (tm *TaskManager) watch(ctx context.Context, taskID string) (<-chan snevent.Event, func()) {
+	tm.subOnce.Do(func() {
+		// Without a subscription, waits fall back to polling.
+		_ = tm.client.SubscribeToAllEvents(context.WithoutCancel(ctx), tm.dispatch)
+	})
+	ch := make(chan snevent.Event, 32)
+	tm.mu.Lock()
+	tm.watches[taskID] = append(tm.watches[taskID], ch)
+	tm.mu.Unlock()
+	return ch, func() {
+		tm.mu.Lock()
+		defer tm.mu.Unlock()
+		chans := tm.watches[taskID]
+		for i, c := range chans {
+			if c == ch {
+				chans = append(chans[:i], chans[i+1:]...)
+				break
 			}
+		}
+		if len(chans) == 0 {
+			delete(tm.watches, taskID)
+		} else {
+			tm.watches[taskID] = chans
+		}
+	}
+}
 
-			switch string(entry.Status) {
-			case "COMPLETED":
-				return &TaskInfo{
-					TaskID: taskID,
-				}, nil
-			case "FAILED":
-				if entry.Error != nil {
-					return nil, fmt.Errorf("task %s failed: %v (tx: %s)", taskID, entry.Error, entry.TxHash)
-				}
-				return nil, fmt.Errorf("task %s failed (tx: %s)", taskID, entry.TxHash)
-			case "ACTIVE", "PENDING":
-				// Keep waiting
-				continue
-			default:
-				// Unknown status; keep waiting conservatively
+func (tm *TaskManager) dispatch(_ context.Context, e snevent.Event) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	for _, ch := range tm.watches[e.TaskID] {
+		// Never block the SDK event bus; polling recovers a dropped event.
+		select {
+		case ch <- e:
+		default:
+		}
+	}
+}
+
+// terminalStatus reports the status implied by a completion or failure event.
+func terminalStatus(events []snevent.Event) (task.TaskStatus, bool) {
+	for i := len(events) - 1; i >= 0; i-- {
+		switch events[i].Type {
+		case snevent.SDKTaskCompleted:
+			return task.StatusCompleted, true
+		case snevent.SDKTaskFailed:
+			return task.StatusFailed, true
+		}
+	}
+	return "", false
+}
+
+// newTaskInfo builds a TaskInfo from the SDK entry, preferring its event log
+// over the events seen by subscription unless the latter is longer.
+func newTaskInfo(taskID string, entry *task.TaskEntry, seen []snevent.Event, start time.Time) *TaskInfo {
+	info := &TaskInfo{TaskID: taskID, StartedAt: start, FinishedAt: time.Now()}
+	events := seen
+	if entry != nil {
+		info.ActionID = entry.ActionID
+		info.TaskType = string(entry.TaskType)
+		info.Status = string(entry.Status)
+		info.TxHash = entry.TxHash
+		info.Err = entry.Error
+		if !entry.CreatedAt.IsZero() {
+			info.StartedAt = entry.CreatedAt
+		}
+		if len(entry.Events) >= len(seen) {
+			events = entry.Events
+		}
+	}
+	info.Duration = info.FinishedAt.Sub(info.StartedAt)
+
+	attempts := make(map[int]int)
+	for _, e := range events {
+		info.Events = append(info.Events, convertEvent(e))
+		if info.ActionID == "" {
+			info.ActionID = e.ActionID
+		}
+		switch e.Type {
+		case snevent.SDKRegistrationAttempt, snevent.SDKDownloadAttempt:
+			iteration := int(int64Value(e.Data[snevent.KeyIteration], int64(len(info.Supernodes)+1)))
+			attempts[iteration] = len(info.Supernodes)
+			info.Supernodes = append(info.Supernodes, SupernodeAttempt{
+				Address:   stringValue(e.Data[snevent.KeySupernodeAddress]),
+				Endpoint:  stringValue(e.Data[snevent.KeySupernode]),
+				Iteration: iteration,
+			})
+		case snevent.SDKRegistrationSuccessful, snevent.SDKRegistrationFailure, snevent.SDKDownloadFailure:
+			idx, ok := attempts[int(int64Value(e.Data[snevent.KeyIteration], 0))]
+			if !ok {
 				continue
 			}
+			if e.Type == snevent.SDKRegistrationSuccessful {
+				info.Supernodes[idx].Succeeded = true
+			} else {
+				info.Supernodes[idx].Error = stringValue(e.Data[snevent.KeyError])
+			}
+		case snevent.SDKTaskTxHashReceived:
+			if info.TxHash == "" {
+				info.TxHash = stringValue(e.Data[snevent.KeyTxHash])
+			}
+		case snevent.SDKTaskFailed:
+			if info.Err == nil {
+				if msg := stringValue(e.Data[snevent.KeyError]); msg != "" {
+					info.Err = errors.New(msg)
+				}
+			}
+		}
+	}
+	// A download that completed succeeded on its last attempt.
+	if info.Status == string(task.StatusCompleted) && len(info.Supernodes) > 0 {
+		last := &info.Supernodes[len(info.Supernodes)-1]
+		if last.Error == "" {
+			last.Succeeded = true
 		}
 	}
+	return info
+}
+
+func stringValue(v any) string {
+	s, _ := v.(string)
+	return s
+}
+
+// WaitTask waits for a supernode task started by this client (for example
+// from a task event) and returns its full status.
+func (c *Client) WaitTask(ctx context.Context, taskID string) (*TaskInfo, error) {
+	return c.tasks.Wait(ctx, taskID)
 }
@@ -0,0 +1,118 @@
+package cascade
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	snevent "github.com/LumeraProtocol/supernode/v2/sdk/event"
+	"github.com/LumeraProtocol/supernode/v2/sdk/task"
+	"github.com/stretchr/testify/require"
+
+	"github.com/LumeraProtocol/sdk-go/types"
+)
+
+// fakeTaskSN serves a settable task entry and captures the event subscription.
+type fakeTaskSN struct {
+	fakeSNClient
+
+	mu      sync.Mutex
+	entry   *task.TaskEntry
+	handler snevent.Handler
+}
+
+func (f *fakeTaskSN) GetTask(_ context.Context, _ string) (*task.TaskEntry, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.entry == nil {
+		return nil, false
+	}
+	entry := *f.entry
+	return &entry, true
+}
+
+func (f *fakeTaskSN) SubscribeToAllEvents(_ context.Context, handler snevent.Handler) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.handler = handler
+	return nil
+}
+
+// emit records e on the task entry and publishes it, as the SDK does.
+func (f *fakeTaskSN) emit(e snevent.Event, status task.TaskStatus) {
+	f.mu.Lock()
+	f.entry.Events = append(f.entry.Events, e)
+	f.entry.Status = status
+	handler := f.handler
+	f.mu.Unlock()
+	handler(context.Background(), e)
+}
+
+func (f *fakeTaskSN) subscribed() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.handler != nil
+}
+
+func TestWaitCompletesOnEvent(t *testing.T) {
+	sn := &fakeTaskSN{entry: &task.TaskEntry{TaskID: "t1", ActionID: "9", TaskType: task.TaskTypeCascade, Status: task.StatusActive}}
+	tm := NewTaskManager(sn, WithPollInterval(time.Hour))
+
+	go func() {
+		for !sn.subscribed() {
+			time.Sleep(time.Millisecond)
+		}
+		sn.emit(snevent.Event{Type: snevent.SDKRegistrationAttempt, TaskID: "t1", Data: snevent.EventData{
+			snevent.KeySupernode: "sn1:4444", snevent.KeySupernodeAddress: "lumera1a", snevent.KeyIteration: 1}}, task.StatusActive)
+		sn.emit(snevent.Event{Type: snevent.SDKRegistrationFailure, TaskID: "t1", Data: snevent.EventData{
+			snevent.KeyIteration: 1, snevent.KeyError: "unreachable"}}, task.StatusActive)
+		sn.emit(snevent.Event{Type: snevent.SDKRegistrationAttempt, TaskID: "t1", Data: snevent.EventData{
+			snevent.KeySupernode: "sn2:4444", snevent.KeySupernodeAddress: "lumera1b", snevent.KeyIteration: 2}}, task.StatusActive)
+		sn.emit(snevent.Event{Type: snevent.SDKRegistrationSuccessful, TaskID: "t1", Data: snevent.EventData{snevent.KeyIteration: 2}}, task.StatusActive)
+		sn.emit(snevent.Event{Type: snevent.SDKTaskTxHashReceived, TaskID: "t1", Data: snevent.EventData{snevent.KeyTxHash: "ABC"}}, task.StatusActive)
+		sn.emit(snevent.Event{Type: snevent.SDKTaskCompleted, TaskID: "t1"}, task.StatusCompleted)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	info, err := tm.Wait(ctx, "t1")
+	require.NoError(t, err)
+	require.Equal(t, "COMPLETED", info.Status)
+	require.Equal(t, "9", info.ActionID)
+	require.Equal(t, "ABC", info.TxHash)
+	require.Len(t, info.Events, 6)
+	require.Equal(t, []SupernodeAttempt{
+		{Address: "lumera1a", Endpoint: "sn1:4444", Iteration: 1, Error: "unreachable"},
+		{Address: "lumera1b", Endpoint: "sn2:4444", Iteration: 2, Succeeded: true},
+	}, info.Supernodes)
+	require.Positive(t, info.Duration)
+}
+
+func TestWaitFailureCarriesStatus(t *testing.T) {
+	cause := errors.New("all supernodes rejected")
+	sn := &fakeTaskSN{entry: &task.TaskEntry{TaskID: "t2", Status: task.StatusFailed, TxHash: "TX", Error: cause}}
+	tm := NewTaskManager(sn)
+
+	info, err := tm.Wait(context.Background(), "t2")
+	require.ErrorIs(t, err, types.ErrTaskFailed)
+	require.ErrorIs(t, err, cause)
+	var taskErr *TaskError
+	require.ErrorAs(t, err, &taskErr)
+	require.Same(t, info, taskErr.Task)
+	require.Equal(t, "TX", info.TxHash)
+}
+
+func TestWaitTimeouts(t *testing.T) {
+	sn := &fakeTaskSN{}
+	tm := NewTaskManager(sn, WithPollInterval(5*time.Millisecond), WithNotFoundTimeout(20*time.Millisecond))
+	_, err := tm.Wait(context.Background(), "missing")
+	require.ErrorIs(t, err, types.ErrNotFound)
+
+	sn.entry = &task.TaskEntry{TaskID: "t3", Status: task.StatusActive}
+	tm = NewTaskManager(sn, WithPollInterval(5*time.Millisecond), WithTaskTimeout(30*time.Millisecond))
+	info, err := tm.Wait(context.Background(), "t3")
+	require.ErrorIs(t, err, types.ErrTimeout)
+	require.Equal(t, "ACTIVE", info.Status)
+}
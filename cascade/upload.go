@@ -1,9 +1,11 @@
 package cascade
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -15,6 +17,8 @@ import (
 	"github.com/LumeraProtocol/sdk-go/blockchain"
 	sdkEvent "github.com/LumeraProtocol/sdk-go/cascade/event"
 	"github.com/LumeraProtocol/sdk-go/types"
+	"github.com/LumeraProtocol/supernode/v2/sdk/task"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
 // UploadOptions configures cascade upload
@@ -24,6 +28,23 @@ type UploadOptions struct {
 	ICACreatorAddress string // optional ICA creator address used in MsgRequestAction
 	AppPubkey         []byte // optional app pubkey for ICA creator validation
 	ICASendFunc       ICASendFunc
+	// Journal, when set, records each upload stage so Resume can continue
+	// after a crash. ID, if set, names the journal entry.
+	Journal *Journal
+	// Progress, when set, receives progress reports for this upload.
+	Progress func(Progress)
+
+	progress *progressTracker
+	// broadcast, when set, is called with the hash of the registration tx
+	// as soon as it is broadcast, before it is confirmed.
+	broadcast func(txHash string)
+}
+
+func (o *UploadOptions) tracker() *progressTracker {
+	if o.progress == nil {
+		o.progress = newProgressTracker(o.Progress)
+	}
+	return o.progress
 }
 
 // UploadOption is a functional option for Upload
@@ -61,6 +82,21 @@ func WithAppPubkey(pubkey []byte) UploadOption {
 	}
 }
 
+// WithJournal records the upload's progress in j; see Client.Resume.
+func WithJournal(j *Journal) UploadOption {
+	return func(o *UploadOptions) {
+		o.Journal = j
+	}
+}
+
+// WithProgress reports the progress of this upload to fn. fn runs on the
+// calling goroutine and should return quickly.
+func WithProgress(fn func(Progress)) UploadOption {
+	return func(o *UploadOptions) {
+		o.Progress = fn
+	}
+}
+
 // WithICASendFunc provides a hook to send the request message via ICA.
 func WithICASendFunc(fn ICASendFunc) UploadOption {
 	return func(o *UploadOptions) {
@@ -141,6 +177,10 @@ func (c *Client) SendRequestActionMessage(ctx context.Context, bc *blockchain.Cl
 		},
 	})
 
+	if err := c.checkFunds(ctx, bc, msg.Creator, msg.Price); err != nil {
+		return nil, err
+	}
+
 	c.logf("cascade: submitting request action tx creator=%s memo=%s price=%s expires=%s", msg.Creator, memo, msg.Price, msg.ExpirationTime)
 	fileSizeKbs := int64(0)
 	if msg.FileSizeKbs != "" {
@@ -149,7 +189,14 @@ func (c *Client) SendRequestActionMessage(ctx context.Context, bc *blockchain.Cl
 			fileSizeKbs = parsed
 		}
 	}
-	ar, err := bc.RequestActionTx(ctx, msg.Creator, at, msg.Metadata, msg.Price, msg.ExpirationTime, fileSizeKbs, memo)
+	txHash, err := bc.BroadcastRequestActionTx(ctx, msg.Creator, at, msg.Metadata, msg.Price, msg.ExpirationTime, fileSizeKbs, memo)
+	if err != nil {
+		return nil, err
+	}
+	if options != nil && options.broadcast != nil {
+		options.broadcast(txHash)
+	}
+	ar, err := bc.ConfirmRequestActionTx(ctx, txHash)
 	if err != nil {
 		return nil, err
 	}
@@ -172,19 +219,56 @@ func (c *Client) SendRequestActionMessage(ctx context.Context, bc *blockchain.Cl
 	return ar, err
 }
 
+// checkFunds fails early when creator cannot cover the action price. Balance
+// lookup errors are logged and left for the chain to decide.
+func (c *Client) checkFunds(ctx context.Context, bc *blockchain.Client, creator, price string) error {
+	if bc.Bank == nil || price == "" {
+		return nil
+	}
+	coin, err := sdk.ParseCoinNormalized(price)
+	if err != nil {
+		return fmt.Errorf("request action: invalid price %q: %w", price, err)
+	}
+	// Only spendable funds can pay the fee; vesting accounts hold locked coins.
+	bal, err := bc.Bank.SpendableBalance(ctx, creator, coin.Denom)
+	if err != nil {
+		c.logf("cascade: balance check skipped: %v", err)
+		return nil
+	}
+	if bal.IsLT(coin) {
+		return fmt.Errorf("request action: %s can spend %s, needs %s: %w", creator, bal, coin, types.ErrInsufficientFunds)
+	}
+	return nil
+}
+
 // UploadToSupernode uploads the file bytes to SuperNodes keyed by actionID and waits for completion.
 // Optional signerAddr overrides the bech32 address used for ADR-36 signing.
 // Returns the resulting taskID upon success.
 func (c *Client) UploadToSupernode(ctx context.Context, actionID string, filePath string, signerAddr ...string) (string, error) {
+	adrSigner := ""
+	if len(signerAddr) > 0 {
+		adrSigner = signerAddr[0]
+	}
+	return c.uploadToSupernode(ctx, actionID, filePath, adrSigner, nil, nil)
+}
+
+// UploadToSupernodeWithOptions is UploadToSupernode configured by upload
+// options; WithICACreatorAddress selects the signer and WithProgress reports
+// the transfer.
+func (c *Client) UploadToSupernodeWithOptions(ctx context.Context, actionID string, filePath string, opts ...UploadOption) (string, error) {
+	options := newUploadOptions(opts)
+	return c.uploadToSupernode(ctx, actionID, filePath, options.ICACreatorAddress, nil, options.tracker())
+}
+
+// uploadToSupernode implements UploadToSupernode; started, if set, is called
+// once the supernode task exists.
+func (c *Client) uploadToSupernode(ctx context.Context, actionID, filePath, adrSigner string, started func(taskID string), progress *progressTracker) (string, error) {
 	if actionID == "" || filePath == "" {
 		return "", fmt.Errorf("actionID and filePath are required")
 	}
 
 	// Create a file signature for upload
-	adrSigner := ""
-	if len(signerAddr) > 0 {
-		adrSigner = strings.TrimSpace(signerAddr[0])
-	}
+	adrSigner = strings.TrimSpace(adrSigner)
 	var signature string
 	var err error
 	if adrSigner != "" {
@@ -209,6 +293,11 @@ func (c *Client) UploadToSupernode(ctx context.Context, actionID string, filePat
 	if err != nil {
 		return "", fmt.Errorf("failed to start cascade: %w", err)
 	}
+	if started != nil {
+		started(taskID)
+	}
+	progress.setIDs(actionID, taskID)
+	progress.stage(ProgressUploading, "Cascade upload task started")
 	// emit upload started event
 	c.emitClientEvent(ctx, sdkEvent.Event{
 		Type:     sdkEvent.SDKGoUploadStarted,
@@ -221,10 +310,15 @@ func (c *Client) UploadToSupernode(ctx context.Context, actionID string, filePat
 	})
 
 	// Wait for task completion
-	if task, err := c.tasks.Wait(ctx, taskID); err != nil {
+	var onTick func(*task.TaskEntry)
+	if progress != nil {
+		onTick = progress.task
+	}
+	if task, err := c.tasks.wait(ctx, taskID, onTick); err != nil {
 		return "", fmt.Errorf("cascade failed: %w", err)
 	} else {
 		c.logf("cascade: supernode upload completed action_id=%s task_id=%s", actionID, task.TaskID)
+		progress.stage(ProgressCompleted, "Cascade upload completed")
 		return task.TaskID, nil
 	}
 }
@@ -242,58 +336,215 @@ func fileSizeKB(path string) (int64, error) {
 //  2. SendRequestActionMessage
 //  3. UploadToSupernode
 func (c *Client) Upload(ctx context.Context, creator string, bc *blockchain.Client, filePath string, opts ...UploadOption) (*types.CascadeResult, error) {
+	return c.upload(ctx, creator, bc, filePath, "", newUploadOptions(opts), false)
+}
 
-	// Apply upload options
+func newUploadOptions(opts []UploadOption) *UploadOptions {
 	options := &UploadOptions{Public: false}
 	for _, opt := range opts {
 		opt(options)
 	}
+	return options
+}
+
+// UploadReader uploads size bytes read from r under the file name name. The
+// data is spooled to a temporary file, which is removed once the upload
+// finishes or fails. With WithJournal, a failed upload keeps its temporary
+// file for Resume, which removes it on completion. A negative size streams
+// until EOF. Spooling fails with
+// types.ErrSpoolLimit when it would exceed Config.MaxSpoolBytes.
+func (c *Client) UploadReader(ctx context.Context, creator string, bc *blockchain.Client, name string, r io.Reader, size int64, opts ...UploadOption) (*types.CascadeResult, error) {
+	options := newUploadOptions(opts)
+	f, err := c.spooler().spool(name, r, size)
+	if err != nil {
+		return nil, err
+	}
+	c.logf("cascade: spooled %s (%d bytes) to %s", name, f.size, f.path)
+	entry, msg, meta, err := c.prepareUpload(ctx, creator, f.path, f.dataHash, options, true)
+	if err != nil {
+		// No journal entry refers to the data yet.
+		f.cleanup()
+		return nil, err
+	}
+	res, err := c.continueUpload(ctx, bc, entry, msg, meta, options)
+	if err != nil && options.Journal != nil {
+		// Keep the data for Resume; it no longer counts against the budget.
+		f.release()
+		return nil, err
+	}
+	f.cleanup()
+	return res, err
+}
+
+// UploadBytes uploads data under the file name name; see UploadReader.
+func (c *Client) UploadBytes(ctx context.Context, creator string, bc *blockchain.Client, name string, data []byte, opts ...UploadOption) (*types.CascadeResult, error) {
+	return c.UploadReader(ctx, creator, bc, name, bytes.NewReader(data), int64(len(data)), opts...)
+}
+
+// upload runs the Upload steps. When dataHash is set, the metadata must
+// describe exactly those bytes. spooled marks filePath as a temporary copy
+// owned by the upload.
+func (c *Client) upload(ctx context.Context, creator string, bc *blockchain.Client, filePath, dataHash string, options *UploadOptions, spooled bool) (*types.CascadeResult, error) {
+	entry, msg, meta, err := c.prepareUpload(ctx, creator, filePath, dataHash, options, spooled)
+	if err != nil {
+		return nil, err
+	}
+	return c.continueUpload(ctx, bc, entry, msg, meta, options)
+}
+
+// prepareUpload builds the request message and the journal entry at
+// JournalStageMetadataBuilt.
+func (c *Client) prepareUpload(ctx context.Context, creator, filePath, dataHash string, options *UploadOptions, spooled bool) (*JournalEntry, *actiontypes.MsgRequestAction, []byte, error) {
+	if options.Journal != nil && options.ID != "" {
+		if e, err := options.Journal.Get(options.ID); err == nil {
+			return nil, nil, nil, fmt.Errorf("upload %s is already journaled at stage %s; use Resume", e.ID, e.Stage)
+		}
+	}
 
 	// Build message
+	options.tracker().stage(ProgressPreparing, "Building cascade metadata")
 	msg, meta, err := c.CreateRequestActionMessage(ctx, creator, filePath, options)
 	if err != nil {
+		return nil, nil, nil, err
+	}
+	if dataHash != "" {
+		var cm actiontypes.CascadeMetadata
+		if err := json.Unmarshal(meta, &cm); err != nil {
+			return nil, nil, nil, fmt.Errorf("decode metadata: %w", err)
+		}
+		if cm.DataHash != dataHash {
+			return nil, nil, nil, fmt.Errorf("metadata data hash %s does not match spooled data %s", cm.DataHash, dataHash)
+		}
+	}
+	if options.ICASendFunc == nil && (options.ICACreatorAddress != "" || len(options.AppPubkey) > 0) {
+		return nil, nil, nil, fmt.Errorf("ica options require WithICASendFunc")
+	}
+
+	entry := &JournalEntry{
+		ID:          options.ID,
+		Stage:       JournalStageMetadataBuilt,
+		FilePath:    filePath,
+		Memo:        filepath.Base(filePath),
+		Spooled:     spooled,
+		Creator:     msg.Creator,
+		Metadata:    msg.Metadata,
+		Price:       msg.Price,
+		Expiration:  msg.ExpirationTime,
+		FileSizeKbs: msg.FileSizeKbs,
+		AppPubkey:   msg.AppPubkey,
+		Signer:      options.ICACreatorAddress,
+	}
+	if entry.ID == "" {
+		entry.ID = newUploadID()
+	}
+	if err := c.journal(options.Journal, entry); err != nil {
+		return nil, nil, nil, err
+	}
+	return entry, msg, meta, nil
+}
+
+// continueUpload runs the stages after entry.Stage and records each one in
+// options.Journal. msg and meta are only needed before registration.
+func (c *Client) continueUpload(ctx context.Context, bc *blockchain.Client, entry *JournalEntry, msg *actiontypes.MsgRequestAction, meta []byte, options *UploadOptions) (*types.CascadeResult, error) {
+	j := options.Journal
+	fail := func(err error) (*types.CascadeResult, error) {
+		if j != nil {
+			entry.LastError = err.Error()
+			if jerr := j.save(entry); jerr != nil {
+				c.logf("cascade: journal %s: %v", entry.ID, jerr)
+			}
+		}
 		return nil, err
 	}
 
-	// extract filename from path
-	fileName := filepath.Base(filePath)
+	progress := options.tracker()
+	if entry.Stage == JournalStageMetadataBuilt {
+		if err := c.register(ctx, bc, entry, msg, meta, options); err != nil {
+			return fail(err)
+		}
+	}
+
+	if entry.Stage == JournalStageActionRegistered || entry.Stage == JournalStageTaskStarted {
+		// Upload bytes off-chain
+		taskID, err := c.uploadToSupernode(ctx, entry.ActionID, entry.FilePath, entry.Signer, func(taskID string) {
+			entry.Stage = JournalStageTaskStarted
+			entry.TaskID = taskID
+			if err := c.journal(j, entry); err != nil {
+				c.logf("cascade: %v", err)
+			}
+		}, progress)
+		if err != nil {
+			return fail(err)
+		}
+		entry.Stage = JournalStageCompleted
+		entry.TaskID = taskID
+		entry.LastError = ""
+		if err := c.journal(j, entry); err != nil {
+			return nil, err
+		}
+	}
+
+	if entry.Spooled && j != nil {
+		_ = os.RemoveAll(filepath.Dir(entry.FilePath))
+	}
+	return &types.CascadeResult{
+		ActionResult: types.ActionResult{
+			ActionID: entry.ActionID,
+			TxHash:   entry.TxHash,
+			Height:   entry.Height,
+		},
+		TaskID: entry.TaskID,
+	}, nil
+}
 
+// register sends the request action tx of an entry at
+// JournalStageMetadataBuilt and advances it to JournalStageActionRegistered.
+func (c *Client) register(ctx context.Context, bc *blockchain.Client, entry *JournalEntry, msg *actiontypes.MsgRequestAction, meta []byte, options *UploadOptions) error {
+	options.tracker().stage(ProgressRegistering, "Registering action")
 	var ar *types.ActionResult
+	var err error
 	if options.ICASendFunc != nil {
-		ar, err = options.ICASendFunc(ctx, msg, meta, filePath, options)
+		ar, err = options.ICASendFunc(ctx, msg, meta, entry.FilePath, options)
 		if err != nil {
-			return nil, fmt.Errorf("ica request action tx: %w", err)
+			return fmt.Errorf("ica request action tx: %w", err)
 		}
 		if ar == nil || ar.ActionID == "" {
-			return nil, fmt.Errorf("ica send func returned empty action id")
+			return fmt.Errorf("ica send func returned empty action id")
 		}
 	} else {
-		if options.ICACreatorAddress != "" || len(options.AppPubkey) > 0 {
-			return nil, fmt.Errorf("ica options require WithICASendFunc")
+		// Journal the tx hash before confirmation so Resume can find the tx
+		// of an upload interrupted while waiting.
+		options.broadcast = func(txHash string) {
+			entry.TxHash = txHash
+			if err := c.journal(options.Journal, entry); err != nil {
+				c.logf("cascade: %v", err)
+			}
 		}
-		// Broadcast
-		ar, err = c.SendRequestActionMessage(ctx, bc, msg, fileName, options)
+		defer func() { options.broadcast = nil }()
+		ar, err = c.SendRequestActionMessage(ctx, bc, msg, entry.Memo, options)
 		if err != nil {
-			return nil, fmt.Errorf("request action tx: %w", err)
+			return fmt.Errorf("request action tx: %w", err)
 		}
 	}
+	entry.ActionID, entry.TxHash, entry.Height = ar.ActionID, ar.TxHash, ar.Height
+	return c.markRegistered(entry, options)
+}
 
-	// Upload bytes off-chain
-	signer := ""
-	if options.ICACreatorAddress != "" {
-		signer = options.ICACreatorAddress
+// markRegistered records that entry's action is on chain.
+func (c *Client) markRegistered(entry *JournalEntry, options *UploadOptions) error {
+	entry.Stage = JournalStageActionRegistered
+	entry.LastError = ""
+	options.tracker().setIDs(entry.ActionID, "")
+	return c.journal(options.Journal, entry)
+}
+
+func (c *Client) journal(j *Journal, entry *JournalEntry) error {
+	if j == nil {
+		return nil
 	}
-	taskID, err := c.UploadToSupernode(ctx, ar.ActionID, filePath, signer)
-	if err != nil {
-		return nil, err
+	if err := j.save(entry); err != nil {
+		return fmt.Errorf("journal upload %s: %w", entry.ID, err)
 	}
-
-	return &types.CascadeResult{
-		ActionResult: types.ActionResult{
-			ActionID: ar.ActionID,
-			TxHash:   ar.TxHash,
-			Height:   ar.Height,
-		},
-		TaskID: taskID,
-	}, nil
+	c.logf("cascade: journaled upload %s stage=%s action_id=%s", entry.ID, entry.Stage, entry.ActionID)
+	return nil
 }
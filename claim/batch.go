@@ -0,0 +1,461 @@
+package claim
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"go.uber.org/zap"
+
+	"github.com/LumeraProtocol/sdk-go/blockchain"
+	"github.com/LumeraProtocol/sdk-go/constants"
+	"github.com/LumeraProtocol/sdk-go/types"
+)
+
+// Backend is the chain access a Batch needs. *blockchain.Client is adapted by
+// NewChainBackend.
+type Backend interface {
+	GetClaimRecord(ctx context.Context, oldAddress string) (*types.ClaimRecord, error)
+	ClaimTx(ctx context.Context, creator string, oldKey *secp256k1.PrivKey, newAddress, memo string) (*types.ClaimResult, error)
+	DelayedClaimTx(ctx context.Context, creator string, oldKey *secp256k1.PrivKey, newAddress string, tier uint32, memo string) (*types.ClaimResult, error)
+}
+
+type chainBackend struct {
+	*blockchain.Client
+}
+
+// NewChainBackend adapts a blockchain client to the batch Backend.
+func NewChainBackend(bc *blockchain.Client) Backend {
+	return &chainBackend{Client: bc}
+}
+
+func (b *chainBackend) GetClaimRecord(ctx context.Context, oldAddress string) (*types.ClaimRecord, error) {
+	return b.Claim.GetClaimRecord(ctx, oldAddress)
+}
+
+// Status is the outcome of one manifest entry.
+type Status string
+
+const (
+	// StatusClaimed means this batch submitted the claim.
+	StatusClaimed Status = "claimed"
+	// StatusAlreadyClaimed means the record was claimed before this batch.
+	StatusAlreadyClaimed Status = "already_claimed"
+	// StatusNotFound means the chain has no claim record for the old address.
+	StatusNotFound Status = "not_found"
+	// StatusInvalid means the entry could not be parsed or signed.
+	StatusInvalid Status = "invalid"
+	// StatusDuplicate means an earlier entry has the same old address.
+	StatusDuplicate Status = "duplicate"
+	// StatusFailed means the status check or the transaction failed.
+	StatusFailed Status = "failed"
+)
+
+// Done reports whether an entry with this status needs no further attempts.
+func (s Status) Done() bool {
+	return s == StatusClaimed || s == StatusAlreadyClaimed
+}
+
+// Result is one line of the batch report. It never contains the old-chain key.
+type Result struct {
+	Line       int       `json:"line"`
+	OldAddress string    `json:"old_address,omitempty"`
+	NewAddress string    `json:"new_address,omitempty"`
+	Tier       uint32    `json:"tier,omitempty"`
+	Status     Status    `json:"status"`
+	TxHash     string    `json:"tx_hash,omitempty"`
+	Height     int64     `json:"height,omitempty"`
+	Amount     string    `json:"amount,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	Time       time.Time `json:"time"`
+	// Resumed is set for results carried over from an earlier report.
+	Resumed bool `json:"-"`
+}
+
+// Report is the outcome of a batch run in manifest order.
+type Report struct {
+	Results []Result
+}
+
+// Count returns the number of results with the given status.
+func (r *Report) Count(s Status) int {
+	n := 0
+	for _, res := range r.Results {
+		if res.Status == s {
+			n++
+		}
+	}
+	return n
+}
+
+// Pending returns the results that still need attention.
+func (r *Report) Pending() []Result {
+	var out []Result
+	for _, res := range r.Results {
+		if !res.Status.Done() {
+			out = append(out, res)
+		}
+	}
+	return out
+}
+
+// BatchConfig configures a Batch.
+type BatchConfig struct {
+	// Concurrency limits entries processed in parallel (default 4). Claim
+	// record lookups run in parallel; transactions are sent one at a time.
+	Concurrency int
+	// Memo is attached to every claim transaction.
+	Memo string
+	// DefaultAddress receives balances of entries without a new address.
+	DefaultAddress string
+	// ReportPath is a JSON-lines file results are appended to. Entries already
+	// claimed in an existing report are skipped, so an interrupted batch can
+	// be rerun with the same path. Empty disables the report file.
+	ReportPath string
+	// MaxAttempts bounds submissions per entry when the signer's account
+	// sequence is taken by another transaction (default 5).
+	MaxAttempts int
+	// RetryDelay is the wait between such attempts (default 3s).
+	RetryDelay time.Duration
+	// Logger is optional; when set, each outcome is logged.
+	Logger *zap.Logger
+}
+
+// Batch submits claims for a manifest, signing transactions as creator.
+type Batch struct {
+	backend Backend
+	creator string
+	cfg     BatchConfig
+
+	// txMu serializes claim transactions: they are all signed by creator,
+	// and concurrent txs would collide on the account sequence.
+	txMu sync.Mutex
+}
+
+// NewBatch creates a batch claimer.
+func NewBatch(backend Backend, creator string, cfg BatchConfig) (*Batch, error) {
+	if backend == nil {
+		return nil, fmt.Errorf("backend is required")
+	}
+	if err := checkAddress(creator); err != nil {
+		return nil, fmt.Errorf("invalid creator: %w", err)
+	}
+	if cfg.DefaultAddress != "" {
+		if err := checkAddress(cfg.DefaultAddress); err != nil {
+			return nil, fmt.Errorf("invalid default address: %w", err)
+		}
+	}
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = 4
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 5
+	}
+	if cfg.RetryDelay <= 0 {
+		cfg.RetryDelay = 3 * time.Second
+	}
+	return &Batch{backend: backend, creator: creator, cfg: cfg}, nil
+}
+
+// Run processes the manifest entries and returns a report in manifest order.
+// Entries finished in an earlier run (per ReportPath) are not resubmitted.
+// Individual failures are recorded in the report; Run only returns an error
+// when the report file cannot be read or written, or ctx is cancelled.
+func (b *Batch) Run(ctx context.Context, entries []Entry) (*Report, error) {
+	done := map[string]Result{}
+	var out *reportWriter
+	if b.cfg.ReportPath != "" {
+		prev, err := LoadReport(b.cfg.ReportPath)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+		for _, r := range prev {
+			if r.Status.Done() && r.OldAddress != "" {
+				done[r.OldAddress] = r
+			}
+		}
+		if out, err = openReport(b.cfg.ReportPath); err != nil {
+			return nil, err
+		}
+		defer out.Close() //nolint:errcheck
+	}
+
+	results := make([]Result, len(entries))
+	var (
+		wg       sync.WaitGroup
+		sem      = make(chan struct{}, b.cfg.Concurrency)
+		seen     = map[string]int{}
+		writeErr error
+		mu       sync.Mutex
+	)
+	record := func(i int, res Result) {
+		res.Time = time.Now().UTC()
+		results[i] = res
+		b.log(res)
+		if out == nil {
+			return
+		}
+		if err := out.Write(res); err != nil {
+			mu.Lock()
+			if writeErr == nil {
+				writeErr = err
+			}
+			mu.Unlock()
+		}
+	}
+
+	for i, e := range entries {
+		res, key, ok := b.prepare(e)
+		if !ok {
+			record(i, res)
+			continue
+		}
+		if prev, ok := done[res.OldAddress]; ok {
+			prev.Resumed = true
+			results[i] = prev
+			continue
+		}
+		if first, ok := seen[res.OldAddress]; ok {
+			res.Status = StatusDuplicate
+			res.Error = fmt.Sprintf("same old address as line %d", first)
+			record(i, res)
+			continue
+		}
+		seen[res.OldAddress] = e.Line
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return &Report{Results: completed(results)}, ctx.Err()
+		}
+		wg.Add(1)
+		go func(i int, res Result, key *secp256k1.PrivKey) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			record(i, b.claim(ctx, res, key))
+		}(i, res, key)
+	}
+	wg.Wait()
+
+	if writeErr != nil {
+		return &Report{Results: results}, fmt.Errorf("write report: %w", writeErr)
+	}
+	return &Report{Results: results}, ctx.Err()
+}
+
+// prepare parses and signs an entry locally so invalid input never reaches the chain.
+func (b *Batch) prepare(e Entry) (Result, *secp256k1.PrivKey, bool) {
+	res := Result{Line: e.Line, NewAddress: e.NewAddress, Tier: e.Tier}
+	if res.NewAddress == "" {
+		res.NewAddress = b.cfg.DefaultAddress
+	}
+	fail := func(err error) (Result, *secp256k1.PrivKey, bool) {
+		res.Status = StatusInvalid
+		res.Error = err.Error()
+		return res, nil, false
+	}
+	if res.NewAddress == "" {
+		return fail(fmt.Errorf("new address is required"))
+	}
+	key, err := blockchain.ParseOldChainPrivKey(e.OldKey)
+	if err != nil {
+		return fail(err)
+	}
+	oldAddress, _, _, err := blockchain.SignClaim(key, res.NewAddress)
+	if err != nil {
+		return fail(err)
+	}
+	res.OldAddress = oldAddress
+	return res, key, true
+}
+
+func (b *Batch) claim(ctx context.Context, res Result, key *secp256k1.PrivKey) Result {
+	rec, err := b.backend.GetClaimRecord(ctx, res.OldAddress)
+	switch {
+	case errors.Is(err, types.ErrNotFound):
+		res.Status = StatusNotFound
+		return res
+	case err != nil:
+		res.Status = StatusFailed
+		res.Error = err.Error()
+		return res
+	case rec.Claimed:
+		res.Status = StatusAlreadyClaimed
+		res.Amount = rec.Balance.String()
+		if rec.DestAddress != "" {
+			res.NewAddress = rec.DestAddress
+		}
+		return res
+	}
+
+	var out *types.ClaimResult
+	for attempt := 1; ; attempt++ {
+		out, err = b.send(ctx, res, key)
+		if err == nil || !isSequenceMismatch(err) || attempt >= b.cfg.MaxAttempts {
+			break
+		}
+		if werr := wait(ctx, b.cfg.RetryDelay); werr != nil {
+			err = werr
+			break
+		}
+	}
+	if err != nil {
+		res.Status = StatusFailed
+		res.Error = err.Error()
+		return res
+	}
+	res.Status = StatusClaimed
+	res.TxHash = out.TxHash
+	res.Height = out.Height
+	res.Amount = out.Amount.String()
+	return res
+}
+
+// send submits the claim tx of res, one entry at a time.
+func (b *Batch) send(ctx context.Context, res Result, key *secp256k1.PrivKey) (*types.ClaimResult, error) {
+	b.txMu.Lock()
+	defer b.txMu.Unlock()
+	if res.Tier > 0 {
+		return b.backend.DelayedClaimTx(ctx, b.creator, key, res.NewAddress, res.Tier, b.cfg.Memo)
+	}
+	return b.backend.ClaimTx(ctx, b.creator, key, res.NewAddress, b.cfg.Memo)
+}
+
+func (b *Batch) log(res Result) {
+	if b.cfg.Logger == nil {
+		return
+	}
+	fields := []zap.Field{
+		zap.Int("line", res.Line),
+		zap.String("old_address", res.OldAddress),
+		zap.String("status", string(res.Status)),
+	}
+	if res.Error != "" {
+		b.cfg.Logger.Warn("claim entry not completed", append(fields, zap.String("error", res.Error))...)
+		return
+	}
+	b.cfg.Logger.Info("claim entry processed", append(fields, zap.String("tx_hash", res.TxHash))...)
+}
+
+// isSequenceMismatch matches the ante handler error returned when concurrent
+// transactions from one signer reuse an account sequence.
+func isSequenceMismatch(err error) bool {
+	return strings.Contains(err.Error(), "account sequence mismatch")
+}
+
+func wait(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+func checkAddress(addr string) error {
+	hrp, _, err := bech32.DecodeAndConvert(addr)
+	if err != nil {
+		return err
+	}
+	if hrp != constants.LumeraAccountHRP {
+		return fmt.Errorf("expected %s prefix, got %s", constants.LumeraAccountHRP, hrp)
+	}
+	return nil
+}
+
+// completed drops the zero results of entries never started.
+func completed(results []Result) []Result {
+	out := make([]Result, 0, len(results))
+	for _, r := range results {
+		if r.Status != "" {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// LoadReport reads a JSON-lines report. When a manifest line appears more than
+// once the latest result wins; lines left incomplete by an interrupted write are skipped.
+func LoadReport(path string) ([]Result, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("read report: %w", err)
+	}
+	defer f.Close() //nolint:errcheck
+
+	var (
+		out   []Result
+		index = map[int]int{}
+	)
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for sc.Scan() {
+		var r Result
+		if err := json.Unmarshal(sc.Bytes(), &r); err != nil || r.Status == "" {
+			continue
+		}
+		if i, ok := index[r.Line]; ok {
+			out[i] = r
+			continue
+		}
+		index[r.Line] = len(out)
+		out = append(out, r)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("read report: %w", err)
+	}
+	return out, nil
+}
+
+type reportWriter struct {
+	mu sync.Mutex
+	f  *os.File
+}
+
+func openReport(path string) (*reportWriter, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_RDWR, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("open report: %w", err)
+	}
+	// Terminate a line cut short by an interrupted run so new results start
+	// on their own line.
+	if st, err := f.Stat(); err == nil && st.Size() > 0 {
+		last := make([]byte, 1)
+		if _, err := f.ReadAt(last, st.Size()-1); err == nil && last[0] != '\n' {
+			if _, err := f.Write([]byte{'\n'}); err != nil {
+				f.Close() //nolint:errcheck
+				return nil, fmt.Errorf("open report: %w", err)
+			}
+		}
+	}
+	return &reportWriter{f: f}, nil
+}
+
+// Write appends one result and syncs it so a crash loses at most that line.
+func (w *reportWriter) Write(r Result) error {
+	line, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if _, err := w.f.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	return w.f.Sync()
+}
+
+func (w *reportWriter) Close() error {
+	return w.f.Close()
+}
@@ -0,0 +1,236 @@
+package claim
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/stretchr/testify/require"
+
+	"github.com/LumeraProtocol/sdk-go/blockchain"
+	"github.com/LumeraProtocol/sdk-go/types"
+)
+
+type fakeBackend struct {
+	mu        sync.Mutex
+	records   map[string]*types.ClaimRecord
+	claims    []string
+	seqErrors int
+}
+
+func (f *fakeBackend) GetClaimRecord(_ context.Context, oldAddress string) (*types.ClaimRecord, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	rec, ok := f.records[oldAddress]
+	if !ok {
+		return nil, types.ErrNotFound
+	}
+	cp := *rec
+	return &cp, nil
+}
+
+func (f *fakeBackend) ClaimTx(ctx context.Context, creator string, key *secp256k1.PrivKey, newAddress, memo string) (*types.ClaimResult, error) {
+	return f.DelayedClaimTx(ctx, creator, key, newAddress, 0, memo)
+}
+
+func (f *fakeBackend) DelayedClaimTx(_ context.Context, _ string, key *secp256k1.PrivKey, newAddress string, _ uint32, _ string) (*types.ClaimResult, error) {
+	old, _, _, err := blockchain.SignClaim(key, newAddress)
+	if err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.seqErrors > 0 {
+		f.seqErrors--
+		return nil, fmt.Errorf("broadcast tx: tx failed with code 32: account sequence mismatch, expected 8, got 7")
+	}
+	rec := f.records[old]
+	rec.Claimed = true
+	rec.DestAddress = newAddress
+	f.claims = append(f.claims, old)
+	return &types.ClaimResult{TxHash: "TX" + old, Height: 10, OldAddress: old, NewAddress: newAddress, Amount: rec.Balance}, nil
+}
+
+// seqBackend fails claim txs that overlap, as the chain rejects concurrent
+// txs signed with the same account sequence.
+type seqBackend struct {
+	*fakeBackend
+	inFlight atomic.Int32
+}
+
+func (s *seqBackend) ClaimTx(ctx context.Context, creator string, key *secp256k1.PrivKey, newAddress, memo string) (*types.ClaimResult, error) {
+	return s.DelayedClaimTx(ctx, creator, key, newAddress, 0, memo)
+}
+
+func (s *seqBackend) DelayedClaimTx(ctx context.Context, creator string, key *secp256k1.PrivKey, newAddress string, tier uint32, memo string) (*types.ClaimResult, error) {
+	n := s.inFlight.Add(1)
+	defer s.inFlight.Add(-1)
+	time.Sleep(5 * time.Millisecond)
+	if n > 1 {
+		return nil, fmt.Errorf("broadcast tx: tx failed with code 32: account sequence mismatch, expected 8, got 7")
+	}
+	return s.fakeBackend.DelayedClaimTx(ctx, creator, key, newAddress, tier, memo)
+}
+
+func testKey(t *testing.T, seed string) (*secp256k1.PrivKey, string) {
+	t.Helper()
+	key := secp256k1.GenPrivKeyFromSecret([]byte(seed))
+	old, err := blockchain.OldChainAddress(hex.EncodeToString(key.PubKey().Bytes()))
+	require.NoError(t, err)
+	return key, old
+}
+
+func testAddress(t *testing.T, b byte) string {
+	t.Helper()
+	addr, err := bech32.ConvertAndEncode("lumera", []byte(strings.Repeat(string(rune(b)), 20)))
+	require.NoError(t, err)
+	return addr
+}
+
+func TestParseManifests(t *testing.T) {
+	dest := testAddress(t, 1)
+
+	csvEntries, err := ParseCSVManifest(strings.NewReader("# legacy holders\ntier,old_key,new_address\n2,aa," + dest + "\n\n,bb,\n"))
+	require.NoError(t, err)
+	require.Len(t, csvEntries, 2)
+	require.Equal(t, Entry{Line: 3, OldKey: "aa", NewAddress: dest, Tier: 2}, csvEntries[0])
+	require.Equal(t, Entry{Line: 5, OldKey: "bb"}, csvEntries[1])
+
+	plain, err := ParseCSVManifest(strings.NewReader("cc, " + dest + "\n"))
+	require.NoError(t, err)
+	require.Equal(t, []Entry{{Line: 1, OldKey: "cc", NewAddress: dest}}, plain)
+
+	_, err = ParseCSVManifest(strings.NewReader("dd," + dest + ",x\n"))
+	require.ErrorContains(t, err, "invalid tier")
+
+	jsonEntries, err := ParseJSONManifest(strings.NewReader(`[{"old_key":" ee ","new_address":"` + dest + `","tier":1}]`))
+	require.NoError(t, err)
+	require.Equal(t, []Entry{{Line: 1, OldKey: "ee", NewAddress: dest, Tier: 1}}, jsonEntries)
+}
+
+func TestBatchRunAndResume(t *testing.T) {
+	creator := testAddress(t, 9)
+	dest := testAddress(t, 2)
+	keyA, oldA := testKey(t, "a")
+	keyB, oldB := testKey(t, "b")
+	keyC, oldC := testKey(t, "c")
+	_, oldD := testKey(t, "d")
+	balance := sdk.NewCoins(sdk.NewInt64Coin("ulume", 100))
+
+	backend := &fakeBackend{
+		records: map[string]*types.ClaimRecord{
+			oldA: {OldAddress: oldA, Balance: balance},
+			oldB: {OldAddress: oldB, Balance: balance, Claimed: true, DestAddress: creator},
+			oldD: {OldAddress: oldD, Balance: balance},
+		},
+		seqErrors: 1,
+	}
+	hexKey := func(k *secp256k1.PrivKey) string { return hex.EncodeToString(k.Key) }
+	entries := []Entry{
+		{Line: 1, OldKey: hexKey(keyA), NewAddress: dest},
+		{Line: 2, OldKey: hexKey(keyB)},
+		{Line: 3, OldKey: hexKey(keyC), NewAddress: dest},
+		{Line: 4, OldKey: "not-a-key", NewAddress: dest},
+		{Line: 5, OldKey: hexKey(keyA), NewAddress: dest},
+	}
+
+	reportPath := filepath.Join(t.TempDir(), "report.jsonl")
+	batch, err := NewBatch(backend, creator, BatchConfig{
+		Concurrency:    2,
+		DefaultAddress: creator,
+		ReportPath:     reportPath,
+		RetryDelay:     time.Millisecond,
+	})
+	require.NoError(t, err)
+
+	report, err := batch.Run(context.Background(), entries)
+	require.NoError(t, err)
+	require.Len(t, report.Results, 5)
+	require.Equal(t, StatusClaimed, report.Results[0].Status)
+	require.Equal(t, "TX"+oldA, report.Results[0].TxHash)
+	require.Equal(t, "100ulume", report.Results[0].Amount)
+	require.Equal(t, StatusAlreadyClaimed, report.Results[1].Status)
+	require.Equal(t, StatusNotFound, report.Results[2].Status)
+	require.Equal(t, StatusInvalid, report.Results[3].Status)
+	require.Equal(t, StatusDuplicate, report.Results[4].Status)
+	require.Equal(t, []string{oldA}, backend.claims)
+	require.Len(t, report.Pending(), 3)
+
+	data, err := os.ReadFile(reportPath)
+	require.NoError(t, err)
+	require.NotContains(t, string(data), hexKey(keyA))
+
+	// Simulate an interrupted write, then resume: finished entries are reused
+	// and the not-found entry is retried.
+	f, err := os.OpenFile(reportPath, os.O_APPEND|os.O_WRONLY, 0o600)
+	require.NoError(t, err)
+	_, err = f.WriteString(`{"line":3,"old_addr`)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	backend.records[oldC] = &types.ClaimRecord{OldAddress: oldC, Balance: balance}
+
+	report, err = batch.Run(context.Background(), entries[:3])
+	require.NoError(t, err)
+	require.True(t, report.Results[0].Resumed)
+	require.True(t, report.Results[1].Resumed)
+	require.Equal(t, StatusClaimed, report.Results[2].Status)
+	require.Equal(t, []string{oldA, oldC}, backend.claims)
+
+	saved, err := LoadReport(reportPath)
+	require.NoError(t, err)
+	require.Len(t, saved, 5)
+	for _, r := range saved {
+		if r.OldAddress == oldC {
+			require.Equal(t, StatusClaimed, r.Status)
+		}
+	}
+}
+
+func TestBatchGivesUpOnSequenceMismatch(t *testing.T) {
+	creator := testAddress(t, 9)
+	key, old := testKey(t, "a")
+	backend := &fakeBackend{
+		records:   map[string]*types.ClaimRecord{old: {OldAddress: old}},
+		seqErrors: 10,
+	}
+	batch, err := NewBatch(backend, creator, BatchConfig{MaxAttempts: 2, RetryDelay: time.Millisecond})
+	require.NoError(t, err)
+
+	report, err := batch.Run(context.Background(), []Entry{{Line: 1, OldKey: hex.EncodeToString(key.Key), NewAddress: creator}})
+	require.NoError(t, err)
+	require.Equal(t, StatusFailed, report.Results[0].Status)
+	require.Contains(t, report.Results[0].Error, "sequence mismatch")
+	require.Equal(t, 8, backend.seqErrors)
+
+	_, err = NewBatch(backend, "cosmos1xyz", BatchConfig{})
+	require.ErrorContains(t, err, "invalid creator")
+}
+
+func TestBatchSerializesClaimTxs(t *testing.T) {
+	creator := testAddress(t, 9)
+	balance := sdk.NewCoins(sdk.NewInt64Coin("ulume", 100))
+	backend := &seqBackend{fakeBackend: &fakeBackend{records: map[string]*types.ClaimRecord{}}}
+	var entries []Entry
+	for i := 0; i < 12; i++ {
+		key, old := testKey(t, fmt.Sprintf("k%d", i))
+		backend.records[old] = &types.ClaimRecord{OldAddress: old, Balance: balance}
+		entries = append(entries, Entry{Line: i + 1, OldKey: hex.EncodeToString(key.Key), NewAddress: creator, Tier: uint32(i % 2)})
+	}
+	batch, err := NewBatch(backend, creator, BatchConfig{Concurrency: 6, MaxAttempts: 1})
+	require.NoError(t, err)
+
+	report, err := batch.Run(context.Background(), entries)
+	require.NoError(t, err)
+	require.Equal(t, len(entries), report.Count(StatusClaimed))
+	require.Len(t, backend.claims, len(entries))
+}
@@ -0,0 +1,117 @@
+// Package claim provides bulk claim tooling on top of the chain claim module:
+// manifest parsing and a resumable batch claimer.
+package claim
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Entry is one manifest line: an old-chain key and where to send its balance.
+type Entry struct {
+	// Line is the 1-based position in the manifest, used in reports.
+	Line int `json:"-"`
+	// OldKey is the old-chain private key, hex or WIF.
+	OldKey string `json:"old_key"`
+	// NewAddress is the destination Lumera address; empty uses the
+	// batch DefaultAddress.
+	NewAddress string `json:"new_address"`
+	// Tier selects a delayed claim when non-zero.
+	Tier uint32 `json:"tier"`
+}
+
+// LoadManifest reads a manifest file, choosing JSON for a .json extension and
+// CSV otherwise.
+func LoadManifest(path string) ([]Entry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open manifest: %w", err)
+	}
+	defer f.Close() //nolint:errcheck
+
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		return ParseJSONManifest(f)
+	}
+	return ParseCSVManifest(f)
+}
+
+// ParseJSONManifest decodes a JSON array of entries.
+func ParseJSONManifest(r io.Reader) ([]Entry, error) {
+	var entries []Entry
+	if err := json.NewDecoder(r).Decode(&entries); err != nil {
+		return nil, fmt.Errorf("decode manifest: %w", err)
+	}
+	for i := range entries {
+		entries[i].Line = i + 1
+		entries[i].OldKey = strings.TrimSpace(entries[i].OldKey)
+		entries[i].NewAddress = strings.TrimSpace(entries[i].NewAddress)
+	}
+	return entries, nil
+}
+
+// ParseCSVManifest decodes CSV rows of old_key[,new_address[,tier]]. An
+// optional header row may name the columns in any order; blank lines and
+// lines starting with '#' are ignored.
+func ParseCSVManifest(r io.Reader) ([]Entry, error) {
+	cr := csv.NewReader(r)
+	cr.Comment = '#'
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	cols := map[string]int{"old_key": 0, "new_address": 1, "tier": 2}
+	var entries []Entry
+	for first := true; ; first = false {
+		rec, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read manifest: %w", err)
+		}
+		line, _ := cr.FieldPos(0)
+		if first && isHeader(rec) {
+			cols = map[string]int{}
+			for i, name := range rec {
+				cols[strings.ToLower(strings.TrimSpace(name))] = i
+			}
+			if _, ok := cols["old_key"]; !ok {
+				return nil, fmt.Errorf("manifest header has no old_key column")
+			}
+			continue
+		}
+
+		field := func(name string) string {
+			i, ok := cols[name]
+			if !ok || i >= len(rec) {
+				return ""
+			}
+			return strings.TrimSpace(rec[i])
+		}
+		e := Entry{Line: line, OldKey: field("old_key"), NewAddress: field("new_address")}
+		if t := field("tier"); t != "" {
+			tier, err := strconv.ParseUint(t, 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid tier %q", line, t)
+			}
+			e.Tier = uint32(tier)
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+func isHeader(rec []string) bool {
+	for _, f := range rec {
+		if strings.EqualFold(strings.TrimSpace(f), "old_key") {
+			return true
+		}
+	}
+	return false
+}
@@ -3,12 +3,14 @@ package client
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"github.com/cosmos/cosmos-sdk/crypto/keyring"
 	"go.uber.org/zap"
 
 	"github.com/LumeraProtocol/sdk-go/blockchain"
 	"github.com/LumeraProtocol/sdk-go/cascade"
+	"github.com/LumeraProtocol/sdk-go/sense"
 )
 
 // Client provides unified access to Lumera blockchain and storage
@@ -16,11 +18,16 @@ type Client struct {
 	// High-level modules
 	Blockchain *blockchain.Client
 	Cascade    *cascade.Client
+	Sense      *sense.Client
 
 	// Configuration
 	config  *Config
 	keyring keyring.Keyring
 	logger  *zap.Logger
+
+	upgradeMu          sync.Mutex
+	upgradeWatcher     *blockchain.UpgradeWatcher
+	stopUpgradeWatcher context.CancelFunc
 }
 
 // New creates a new unified Lumera client
@@ -48,6 +55,10 @@ func New(ctx context.Context, cfg Config, kr keyring.Keyring, opts ...Option) (*
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize blockchain client: %w", err)
 	}
+	if err := checkChainVersions(ctx, blockchainClient.Upgrade, cfg); err != nil {
+		_ = blockchainClient.Close()
+		return nil, err
+	}
 
 	// Initialize cascade client (wraps SuperNode SDK)
 	cascadeClient, cascadeErr := cascade.New(ctx, cascade.Config{
@@ -57,6 +68,9 @@ func New(ctx context.Context, cfg Config, kr keyring.Keyring, opts ...Option) (*
 		KeyName:  cfg.KeyName,
 		Timeout:  cfg.StorageTimeout,
 		LogLevel: cfg.LogLevel,
+
+		SpoolDir:      cfg.SpoolDir,
+		MaxSpoolBytes: cfg.MaxSpoolBytes,
 	}, kr)
 	if cascadeErr != nil {
 		if closeErr := blockchainClient.Close(); closeErr != nil {
@@ -67,10 +81,20 @@ func New(ctx context.Context, cfg Config, kr keyring.Keyring, opts ...Option) (*
 	if cfg.Logger != nil {
 		cascadeClient.SetLogger(cfg.Logger)
 	}
+	cascadeClient.SetBlockchain(blockchainClient)
+
+	// Sense client (chain side only until supernodes expose a sense service)
+	senseClient, err := sense.New(sense.Config{Logger: cfg.Logger}, blockchainClient)
+	if err != nil {
+		_ = cascadeClient.Close()
+		_ = blockchainClient.Close()
+		return nil, fmt.Errorf("failed to initialize sense client: %w", err)
+	}
 
 	return &Client{
 		Blockchain: blockchainClient,
 		Cascade:    cascadeClient,
+		Sense:      senseClient,
 		config:     &cfg,
 		keyring:    kr,
 		logger:     cfg.Logger,
@@ -81,6 +105,12 @@ func New(ctx context.Context, cfg Config, kr keyring.Keyring, opts ...Option) (*
 func (c *Client) Close() error {
 	var errs []error
 
+	c.upgradeMu.Lock()
+	if c.stopUpgradeWatcher != nil {
+		c.stopUpgradeWatcher()
+	}
+	c.upgradeMu.Unlock()
+
 	if c.Blockchain != nil {
 		if err := c.Blockchain.Close(); err != nil {
 			errs = append(errs, fmt.Errorf("blockchain close: %w", err))
@@ -37,8 +37,30 @@ type Config struct {
 
 	// Logger is optional; when set, SDK operations emit diagnostics.
 	Logger *zap.Logger
+
+	// SpoolDir holds temporary files for cascade reader uploads. Default is os.TempDir().
+	SpoolDir string
+	// MaxSpoolBytes caps temporary space used by concurrent reader uploads (0 => unlimited).
+	MaxSpoolBytes int64
+
+	// VersionCheck controls the chain compatibility check run by client.New.
+	// Default is VersionCheckWarn.
+	VersionCheck VersionCheck
 }
 
+// VersionCheck selects how client.New reacts to chain modules newer than
+// the SDK knows about.
+type VersionCheck string
+
+const (
+	// VersionCheckWarn logs incompatible modules and continues.
+	VersionCheckWarn VersionCheck = "warn"
+	// VersionCheckFail makes client.New return an error.
+	VersionCheckFail VersionCheck = "fail"
+	// VersionCheckOff skips the check.
+	VersionCheckOff VersionCheck = "off"
+)
+
 // WaitTxConfig configures how the SDK waits for transaction inclusion.
 type WaitTxConfig struct {
 	// SubscriberSetupTimeout defines how long we wait for the websocket subscription to become
@@ -86,12 +108,20 @@ func (c *Config) Validate() error {
 		c.LogLevel = level
 	}
 
+	switch c.VersionCheck {
+	case "":
+		c.VersionCheck = VersionCheckWarn
+	case VersionCheckWarn, VersionCheckFail, VersionCheckOff:
+	default:
+		return fmt.Errorf("version_check must be one of: warn, fail, off")
+	}
+
 	// Set defaults
 	if c.BlockchainTimeout == 0 {
 		c.BlockchainTimeout = 10 * time.Second
 	}
 	if c.StorageTimeout == 0 {
-		c.StorageTimeout = 5 * time.Minute
+		c.StorageTimeout = DefaultStorageTimeout
 	}
 	if c.MaxRecvMsgSize == 0 {
 		c.MaxRecvMsgSize = 1024 * 1024 * 50 // 50MB
@@ -107,6 +137,10 @@ func (c *Config) Validate() error {
 	return nil
 }
 
+// DefaultStorageTimeout bounds the wait for a supernode task. It covers the
+// SuperNode SDK's own limits of 60m for an upload plus 10m of processing.
+const DefaultStorageTimeout = 75 * time.Minute
+
 // Default returns a configuration with sensible defaults for testnet.
 func Default() Config {
 	return Config{
@@ -114,12 +148,13 @@ func Default() Config {
 		GRPCEndpoint:      "localhost:9090",
 		RPCEndpoint:       "http://localhost:26657",
 		BlockchainTimeout: 10 * time.Second,
-		StorageTimeout:    5 * time.Minute,
+		StorageTimeout:    DefaultStorageTimeout,
 		MaxRetries:        3,
 		MaxRecvMsgSize:    1024 * 1024 * 50,
 		MaxSendMsgSize:    1024 * 1024 * 50,
 		LogLevel:          "error",
 		WaitTx:            DefaultWaitTxConfig(),
+		VersionCheck:      VersionCheckWarn,
 	}
 }
 
@@ -8,6 +8,16 @@ type Config = clientconfig.Config
 // WaitTxConfig re-exports the wait-tx config type for backwards compatibility.
 type WaitTxConfig = clientconfig.WaitTxConfig
 
+// VersionCheck re-exports the version check mode type.
+type VersionCheck = clientconfig.VersionCheck
+
+// Version check modes, see config.VersionCheck.
+const (
+	VersionCheckWarn = clientconfig.VersionCheckWarn
+	VersionCheckFail = clientconfig.VersionCheckFail
+	VersionCheckOff  = clientconfig.VersionCheckOff
+)
+
 // DefaultConfig mirrors config.Default.
 func DefaultConfig() Config {
 	return clientconfig.Default()
@@ -87,3 +87,18 @@ func WithLogger(logger *zap.Logger) Option {
 		c.Logger = logger
 	}
 }
+
+// WithVersionCheck sets how New reacts to chain modules newer than the SDK.
+func WithVersionCheck(mode VersionCheck) Option {
+	return func(c *Config) {
+		c.VersionCheck = mode
+	}
+}
+
+// WithSpool sets the directory and byte budget for cascade reader uploads.
+func WithSpool(dir string, maxBytes int64) Option {
+	return func(c *Config) {
+		c.SpoolDir = dir
+		c.MaxSpoolBytes = maxBytes
+	}
+}
@@ -0,0 +1,70 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/LumeraProtocol/sdk-go/blockchain"
+	"github.com/LumeraProtocol/sdk-go/types"
+)
+
+// checkChainVersions compares the chain's module versions with the ones the
+// SDK was built against. Failing to query versions is only logged so that
+// chains without the upgrade query service remain usable.
+func checkChainVersions(ctx context.Context, u *blockchain.UpgradeClient, cfg Config) error {
+	if cfg.VersionCheck == VersionCheckOff {
+		return nil
+	}
+	mismatches, err := u.CheckCompatibility(ctx)
+	if err != nil {
+		if cfg.Logger != nil {
+			cfg.Logger.Warn("chain version check skipped", zap.Error(err))
+		}
+		return nil
+	}
+	if len(mismatches) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(mismatches))
+	for _, m := range mismatches {
+		names = append(names, m.String())
+	}
+	if cfg.VersionCheck == VersionCheckFail {
+		return fmt.Errorf("chain modules newer than sdk: %s: %w", strings.Join(names, ", "), types.ErrIncompatibleChain)
+	}
+	if cfg.Logger != nil {
+		cfg.Logger.Warn("chain modules newer than sdk", zap.Strings("modules", names))
+	}
+	return nil
+}
+
+// OnUpgradeApproaching registers fn to be called once per scheduled upgrade
+// when the chain is within 100 blocks of the upgrade height. The first call
+// starts a background watcher that stops on Close. The returned function
+// unsubscribes fn.
+func (c *Client) OnUpgradeApproaching(fn func(blockchain.UpgradeNotice)) func() {
+	c.upgradeMu.Lock()
+	defer c.upgradeMu.Unlock()
+	if c.upgradeWatcher == nil {
+		c.upgradeWatcher = blockchain.NewUpgradeWatcher(c.Blockchain.Upgrade, 0, 0)
+		ctx, cancel := context.WithCancel(context.Background())
+		c.stopUpgradeWatcher = cancel
+		go func() { _ = c.upgradeWatcher.Run(ctx) }()
+	}
+	return c.upgradeWatcher.Subscribe(fn)
+}
+
+// PendingUpgrade returns the upgrade inside the notice window, if any. It
+// reports false until OnUpgradeApproaching has been called.
+func (c *Client) PendingUpgrade() (blockchain.UpgradeNotice, bool) {
+	c.upgradeMu.Lock()
+	w := c.upgradeWatcher
+	c.upgradeMu.Unlock()
+	if w == nil {
+		return blockchain.UpgradeNotice{}, false
+	}
+	return w.Pending()
+}
@@ -2,3 +2,6 @@ package constants
 
 // LumeraAccountHRP is the bech32 prefix for Lumera account addresses.
 const LumeraAccountHRP = "lumera"
+
+// LumeraValidatorHRP is the bech32 prefix for Lumera validator operator addresses.
+const LumeraValidatorHRP = LumeraAccountHRP + "valoper"
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/LumeraProtocol/sdk-go/claim"
+	lumerasdk "github.com/LumeraProtocol/sdk-go/client"
+	"github.com/LumeraProtocol/sdk-go/constants"
+	sdkcrypto "github.com/LumeraProtocol/sdk-go/pkg/crypto"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	grpcEndpoint := flag.String("grpc-endpoint", "localhost:9090", "Lumera gRPC endpoint")
+	rpcEndpoint := flag.String("rpc-endpoint", "http://localhost:26657", "Lumera RPC endpoint")
+	chainID := flag.String("chain-id", "lumera-testnet-2", "Chain ID")
+	keyringBackend := flag.String("keyring-backend", "os", "Keyring backend: os|file|test")
+	keyringDir := flag.String("keyring-dir", "~/.lumera", "Keyring base directory (actual dir appends keyring-<backend> for file/test)")
+	keyName := flag.String("key-name", "my-key", "Key name in the keyring")
+	manifest := flag.String("manifest", "", "CSV or JSON manifest of old_key,new_address,tier")
+	report := flag.String("report", "claims-report.jsonl", "Results report; rerun with the same path to resume")
+	concurrency := flag.Int("concurrency", 4, "Entries processed in parallel")
+	flag.Parse()
+
+	if strings.TrimSpace(*manifest) == "" {
+		log.Fatal("--manifest is required")
+	}
+	entries, err := claim.LoadManifest(*manifest)
+	if err != nil {
+		log.Fatalf("Failed to load manifest: %v", err)
+	}
+
+	kr, err := sdkcrypto.NewKeyring(sdkcrypto.KeyringParams{
+		AppName: "lumera",
+		Backend: *keyringBackend,
+		Dir:     *keyringDir,
+	})
+	if err != nil {
+		log.Fatalf("Failed to create keyring: %v", err)
+	}
+	address, err := sdkcrypto.AddressFromKey(kr, *keyName, constants.LumeraAccountHRP)
+	if err != nil {
+		log.Fatalf("derive owner address: %v\n", err)
+	}
+
+	client, err := lumerasdk.New(ctx, lumerasdk.Config{
+		ChainID:      *chainID,
+		GRPCEndpoint: *grpcEndpoint,
+		RPCEndpoint:  *rpcEndpoint,
+		Address:      address,
+		KeyName:      *keyName,
+	}, kr)
+	if err != nil {
+		log.Fatalf("Failed to create client: %v", err)
+	}
+	defer client.Close() //nolint:errcheck
+
+	batch, err := claim.NewBatch(claim.NewChainBackend(client.Blockchain), address, claim.BatchConfig{
+		Concurrency:    *concurrency,
+		DefaultAddress: address,
+		ReportPath:     *report,
+	})
+	if err != nil {
+		log.Fatalf("Failed to create batch: %v", err)
+	}
+
+	res, runErr := batch.Run(ctx, entries)
+	if res != nil {
+		fmt.Printf("Processed %d of %d entries (report: %s)\n", len(res.Results), len(entries), *report)
+		for _, s := range []claim.Status{claim.StatusClaimed, claim.StatusAlreadyClaimed, claim.StatusNotFound, claim.StatusInvalid, claim.StatusDuplicate, claim.StatusFailed} {
+			fmt.Printf("  %-16s %d\n", s, res.Count(s))
+		}
+		for _, p := range res.Pending() {
+			fmt.Printf("  line %d (%s): %s %s\n", p.Line, p.OldAddress, p.Status, p.Error)
+		}
+	}
+	if runErr != nil {
+		log.Fatalf("Batch interrupted: %v", runErr)
+	}
+}
@@ -5,10 +5,13 @@ import (
 	"flag"
 	"fmt"
 	"log"
+	"strings"
 
+	"github.com/LumeraProtocol/sdk-go/blockchain"
 	lumerasdk "github.com/LumeraProtocol/sdk-go/client"
 	"github.com/LumeraProtocol/sdk-go/constants"
 	sdkcrypto "github.com/LumeraProtocol/sdk-go/pkg/crypto"
+	"github.com/LumeraProtocol/sdk-go/types"
 )
 
 func main() {
@@ -20,8 +23,15 @@ func main() {
 	keyringBackend := flag.String("keyring-backend", "os", "Keyring backend: os|file|test")
 	keyringDir := flag.String("keyring-dir", "~/.lumera", "Keyring base directory (actual dir appends keyring-<backend> for file/test)")
 	keyName := flag.String("key-name", "my-key", "Key name in the keyring")
+	oldKey := flag.String("old-key", "", "Old-chain private key (hex or WIF)")
+	newAddress := flag.String("new-address", "", "Destination Lumera address (defaults to the signer address)")
+	tier := flag.Uint("tier", 0, "Vesting tier for a delayed claim (0 claims immediately)")
 	flag.Parse()
 
+	if strings.TrimSpace(*oldKey) == "" {
+		log.Fatal("--old-key is required")
+	}
+
 	params := sdkcrypto.KeyringParams{
 		AppName: "lumera",
 		Backend: *keyringBackend,
@@ -50,7 +60,45 @@ func main() {
 	}
 	defer client.Close() //nolint:errcheck
 
-	fmt.Println("Claim tokens example - to be implemented")
-	fmt.Println("This example will demonstrate claiming tokens from the old chain")
-	// TODO: Implement claim token logic once claim module methods are available.
+	key, err := blockchain.ParseOldChainPrivKey(*oldKey)
+	if err != nil {
+		log.Fatalf("Invalid old-chain key: %v", err)
+	}
+	dest := strings.TrimSpace(*newAddress)
+	if dest == "" {
+		dest = address
+	}
+	oldAddress, pubKeyHex, _, err := blockchain.SignClaim(key, dest)
+	if err != nil {
+		log.Fatalf("Failed to sign claim: %v", err)
+	}
+
+	// Check the claim record before submitting
+	record, err := client.Blockchain.Claim.GetClaimRecord(ctx, oldAddress)
+	if err != nil {
+		log.Fatalf("Failed to get claim record for %s: %v", oldAddress, err)
+	}
+	fmt.Printf("Claim record for %s (pubkey %s):\n", oldAddress, pubKeyHex)
+	fmt.Printf("  Balance: %s\n", record.Balance)
+	fmt.Printf("  Claimed: %t\n", record.Claimed)
+	if record.Claimed {
+		fmt.Printf("  Already claimed to %s at %s\n", record.DestAddress, record.ClaimTime)
+		return
+	}
+
+	var res *types.ClaimResult
+	if *tier > 0 {
+		res, err = client.Blockchain.DelayedClaimTx(ctx, address, key, dest, uint32(*tier), "")
+	} else {
+		res, err = client.Blockchain.ClaimTx(ctx, address, key, dest, "")
+	}
+	if err != nil {
+		log.Fatalf("Claim failed: %v", err)
+	}
+	fmt.Printf("Claimed %s to %s\n", res.Amount, res.NewAddress)
+	fmt.Printf("  TxHash: %s\n", res.TxHash)
+	fmt.Printf("  Height: %d\n", res.Height)
+	if !res.DelayedEndTime.IsZero() {
+		fmt.Printf("  Vesting ends: %s\n", res.DelayedEndTime)
+	}
 }
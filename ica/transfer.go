@@ -0,0 +1,249 @@
+package ica
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	abcitypes "cosmossdk.io/api/cosmos/base/abci/v1beta1"
+	txtypes "cosmossdk.io/api/cosmos/tx/v1beta1"
+	"github.com/LumeraProtocol/sdk-go/blockchain/base"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	transfertypes "github.com/cosmos/ibc-go/v10/modules/apps/transfer/types"
+	clienttypes "github.com/cosmos/ibc-go/v10/modules/core/02-client/types"
+	channeltypes "github.com/cosmos/ibc-go/v10/modules/core/04-channel/types"
+)
+
+// TransferStatus is the outcome of an ICS-20 transfer packet.
+type TransferStatus string
+
+const (
+	// TransferPending means neither an acknowledgement nor a timeout has been relayed yet.
+	TransferPending TransferStatus = "pending"
+	// TransferAcknowledged means the receiving chain credited the tokens.
+	TransferAcknowledged TransferStatus = "acknowledged"
+	// TransferFailed means the receiving chain returned an error ack; the tokens were refunded.
+	TransferFailed TransferStatus = "failed"
+	// TransferTimedOut means the packet timed out; the tokens were refunded.
+	TransferTimedOut TransferStatus = "timed_out"
+)
+
+// TransferResult describes a submitted ICS-20 transfer.
+type TransferResult struct {
+	TxHash string
+	Packet PacketInfo
+	Status TransferStatus
+	// AckError is the error returned by the receiving chain for TransferFailed.
+	AckError string
+}
+
+// TransferClient sends ICS-20 transfers from the chain behind a base client
+// and tracks their packets to acknowledgement or timeout.
+type TransferClient struct {
+	bc          *base.Client
+	pollDelay   time.Duration
+	pollRetries int
+}
+
+// NewTransferClient wraps a base client. pollDelay and pollRetries bound
+// WaitForTransfer; non-positive values use the ICA defaults.
+func NewTransferClient(bc *base.Client, pollDelay time.Duration, pollRetries int) *TransferClient {
+	if pollDelay <= 0 {
+		pollDelay = defaultPollDelay
+	}
+	if pollRetries <= 0 {
+		pollRetries = defaultAckRetries
+	}
+	return &TransferClient{bc: bc, pollDelay: pollDelay, pollRetries: pollRetries}
+}
+
+// NewMsgTransfer builds an ICS-20 MsgTransfer on the transfer port that times
+// out relativeTimeout after now.
+func NewMsgTransfer(sender, receiver, sourceChannel string, token sdk.Coin, relativeTimeout time.Duration, memo string) *transfertypes.MsgTransfer {
+	timeout := uint64(time.Now().Add(relativeTimeout).UnixNano())
+	return transfertypes.NewMsgTransfer(transfertypes.PortID, sourceChannel, token, sender, receiver, clienttypes.ZeroHeight(), timeout, memo)
+}
+
+// IBCDenom returns the ibc/<hash> denom of baseDenom after it travels over
+// each port/channel hop in order, e.g. IBCDenom("ulume", "transfer", "channel-0").
+func IBCDenom(baseDenom string, hops ...string) (string, error) {
+	if len(hops)%2 != 0 {
+		return "", fmt.Errorf("hops must be port/channel pairs")
+	}
+	var trace []transfertypes.Hop
+	for i := 0; i < len(hops); i += 2 {
+		trace = append(trace, transfertypes.NewHop(hops[i], hops[i+1]))
+	}
+	denom := transfertypes.NewDenom(baseDenom, trace...)
+	if err := denom.Validate(); err != nil {
+		return "", err
+	}
+	return denom.IBCDenom(), nil
+}
+
+// Denom resolves an ibc/<hash> denom (or bare hash) to its base denom and trace.
+func (t *TransferClient) Denom(ctx context.Context, ibcDenom string) (*transfertypes.Denom, error) {
+	hash := strings.TrimPrefix(ibcDenom, transfertypes.DenomPrefix+"/")
+	resp, err := transfertypes.NewQueryClient(t.bc.GRPCConn()).Denom(ctx, &transfertypes.QueryDenomRequest{Hash: hash})
+	if err != nil {
+		return nil, fmt.Errorf("query denom %s: %w", ibcDenom, err)
+	}
+	if resp.Denom == nil {
+		return nil, fmt.Errorf("denom %s not found", ibcDenom)
+	}
+	return resp.Denom, nil
+}
+
+// DenomHash returns the hash the chain assigns to a full denom path such as
+// "transfer/channel-0/ulume".
+func (t *TransferClient) DenomHash(ctx context.Context, trace string) (string, error) {
+	resp, err := transfertypes.NewQueryClient(t.bc.GRPCConn()).DenomHash(ctx, &transfertypes.QueryDenomHashRequest{Trace: trace})
+	if err != nil {
+		return "", fmt.Errorf("query denom hash %s: %w", trace, err)
+	}
+	return resp.Hash, nil
+}
+
+// Transfer sends token from sender to receiver over sourceChannel and returns
+// once the transfer tx is included; use WaitForTransfer to track the packet.
+func (t *TransferClient) Transfer(ctx context.Context, sender, receiver, sourceChannel string, token sdk.Coin, relativeTimeout time.Duration, memo string) (*TransferResult, error) {
+	if !token.IsValid() || !token.IsPositive() {
+		return nil, fmt.Errorf("invalid amount %q", token)
+	}
+	if !channeltypes.IsValidChannelID(sourceChannel) {
+		return nil, fmt.Errorf("invalid channel id %q", sourceChannel)
+	}
+	if relativeTimeout <= 0 {
+		relativeTimeout = defaultRelativeTimeout
+	}
+	msg := NewMsgTransfer(sender, receiver, sourceChannel, token, relativeTimeout, memo)
+	txBytes, err := t.bc.BuildAndSignTx(ctx, msg, "")
+	if err != nil {
+		return nil, fmt.Errorf("build and sign tx: %w", err)
+	}
+	txHash, err := t.bc.Broadcast(ctx, txBytes, txtypes.BroadcastMode_BROADCAST_MODE_SYNC)
+	if err != nil {
+		return nil, fmt.Errorf("broadcast tx: %w", err)
+	}
+	txResp, err := t.bc.WaitForTxInclusion(ctx, txHash)
+	if err != nil {
+		return nil, fmt.Errorf("wait for tx inclusion: %w", err)
+	}
+	packet, err := extractPacketInfoFromTxResponse(txResp)
+	if err != nil {
+		return nil, err
+	}
+	return &TransferResult{TxHash: txHash, Packet: packet, Status: TransferPending}, nil
+}
+
+// WaitForTransfer polls the source chain until the packet of res is
+// acknowledged or timed out, and records the outcome in res. A failed ack or a
+// timeout is not an error; callers inspect res.Status.
+func (t *TransferClient) WaitForTransfer(ctx context.Context, res *TransferResult) error {
+	for i := 0; i < t.pollRetries; i++ {
+		status, ackErr, err := t.packetStatus(ctx, res.Packet)
+		if err != nil {
+			return err
+		}
+		if status != TransferPending {
+			res.Status = status
+			res.AckError = ackErr
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(t.pollDelay):
+		}
+	}
+	return fmt.Errorf("transfer %s/%s/%d still pending after %d polls", res.Packet.Port, res.Packet.Channel, res.Packet.Sequence, t.pollRetries)
+}
+
+func (t *TransferClient) packetStatus(ctx context.Context, p PacketInfo) (TransferStatus, string, error) {
+	for _, evtType := range []string{channeltypes.EventTypeAcknowledgePacket, channeltypes.EventTypeTimeoutPacket} {
+		events := []string{
+			fmt.Sprintf("%s.packet_src_port='%s'", evtType, p.Port),
+			fmt.Sprintf("%s.packet_src_channel='%s'", evtType, p.Channel),
+			fmt.Sprintf("%s.packet_sequence='%d'", evtType, p.Sequence),
+		}
+		resp, err := t.bc.GetTxsByEvents(ctx, events, 1, 5)
+		if err != nil {
+			return "", "", fmt.Errorf("query %s events: %w", evtType, err)
+		}
+		if status, ackErr, ok := transferOutcome(resp.GetTxResponses(), evtType, p); ok {
+			return status, ackErr, nil
+		}
+	}
+	return TransferPending, "", nil
+}
+
+// transferOutcome looks for the acknowledge_packet or timeout_packet event of
+// p in txs and classifies it. A relayer tx may carry several packets; the
+// transfer module emits each packet's fungible_token_packet event right after
+// its acknowledge_packet event, so only the one following p's is read.
+func transferOutcome(txs []*abcitypes.TxResponse, evtType string, p PacketInfo) (TransferStatus, string, bool) {
+	seqStr := strconv.FormatUint(p.Sequence, 10)
+	for _, tx := range txs {
+		matched, current := false, false
+		ackErr := ""
+		for _, evt := range tx.GetEvents() {
+			attr := make(map[string]string)
+			for _, a := range evt.GetAttributes() {
+				if key := strings.TrimSpace(decodeEventValue(a.GetKey())); key != "" {
+					attr[key] = strings.TrimSpace(decodeEventValue(a.GetValue()))
+				}
+			}
+			switch strings.TrimSpace(decodeEventValue(evt.GetType_())) {
+			case evtType:
+				current = attr["packet_src_port"] == p.Port && attr["packet_src_channel"] == p.Channel && attr["packet_sequence"] == seqStr
+				matched = matched || current
+			case transfertypes.EventTypePacket:
+				if current {
+					ackErr = attr[transfertypes.AttributeKeyAckError]
+					current = false
+				}
+			}
+		}
+		if !matched {
+			continue
+		}
+		switch {
+		case evtType == channeltypes.EventTypeTimeoutPacket:
+			return TransferTimedOut, "", true
+		case ackErr != "":
+			return TransferFailed, ackErr, true
+		default:
+			return TransferAcknowledged, "", true
+		}
+	}
+	return "", "", false
+}
+
+// FundICA transfers amount from the controller owner account to the
+// interchain account over the controller chain's ICS-20 channel to the host
+// and waits for the acknowledgement. It fails unless the host credited the
+// tokens.
+func (c *Controller) FundICA(ctx context.Context, transferChannel string, amount sdk.Coin) (*TransferResult, error) {
+	icaAddr, err := c.ICAAddress(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tc := NewTransferClient(c.controllerBC, c.cfg.PollDelay, c.cfg.AckRetries)
+	res, err := tc.Transfer(ctx, c.ownerAddr, icaAddr, transferChannel, amount, c.cfg.RelativeTimeout, "")
+	if err != nil {
+		return nil, err
+	}
+	if err := tc.WaitForTransfer(ctx, res); err != nil {
+		return res, err
+	}
+	switch res.Status {
+	case TransferAcknowledged:
+		return res, nil
+	case TransferFailed:
+		return res, fmt.Errorf("fund ica: host rejected transfer: %s", res.AckError)
+	default:
+		return res, fmt.Errorf("fund ica: transfer %s", res.Status)
+	}
+}
@@ -0,0 +1,77 @@
+package ica
+
+import (
+	"strings"
+	"testing"
+
+	abcitypes "cosmossdk.io/api/cosmos/base/abci/v1beta1"
+	abci "cosmossdk.io/api/tendermint/abci"
+)
+
+func packetEvent(evtType, channel, seq string) *abci.Event {
+	return &abci.Event{Type_: evtType, Attributes: []*abci.EventAttribute{
+		{Key: "packet_src_port", Value: "transfer"},
+		{Key: "packet_src_channel", Value: channel},
+		{Key: "packet_sequence", Value: seq},
+	}}
+}
+
+func TestTransferOutcome(t *testing.T) {
+	p := PacketInfo{Port: "transfer", Channel: "channel-3", Sequence: 11}
+
+	ok := &abcitypes.TxResponse{Events: []*abci.Event{
+		packetEvent("acknowledge_packet", "channel-3", "11"),
+		{Type_: "fungible_token_packet", Attributes: []*abci.EventAttribute{{Key: "success", Value: "\x01"}}},
+	}}
+	if status, _, found := transferOutcome([]*abcitypes.TxResponse{ok}, "acknowledge_packet", p); !found || status != TransferAcknowledged {
+		t.Fatalf("expected acknowledged, got %q found=%v", status, found)
+	}
+
+	failed := &abcitypes.TxResponse{Events: []*abci.Event{
+		packetEvent("acknowledge_packet", "channel-3", "11"),
+		{Type_: "fungible_token_packet", Attributes: []*abci.EventAttribute{{Key: "error", Value: "invalid receiver"}}},
+	}}
+	status, ackErr, found := transferOutcome([]*abcitypes.TxResponse{failed}, "acknowledge_packet", p)
+	if !found || status != TransferFailed || ackErr != "invalid receiver" {
+		t.Fatalf("expected failed ack, got %q %q found=%v", status, ackErr, found)
+	}
+
+	// A relayer tx batching another packet's failed ack must not taint ours.
+	batched := &abcitypes.TxResponse{Events: []*abci.Event{
+		packetEvent("acknowledge_packet", "channel-3", "10"),
+		{Type_: "fungible_token_packet", Attributes: []*abci.EventAttribute{{Key: "error", Value: "invalid receiver"}}},
+		packetEvent("acknowledge_packet", "channel-3", "11"),
+		{Type_: "fungible_token_packet", Attributes: []*abci.EventAttribute{{Key: "success", Value: "\x01"}}},
+		packetEvent("acknowledge_packet", "channel-3", "12"),
+		{Type_: "fungible_token_packet", Attributes: []*abci.EventAttribute{{Key: "error", Value: "insufficient funds"}}},
+	}}
+	if status, ackErr, found := transferOutcome([]*abcitypes.TxResponse{batched}, "acknowledge_packet", p); !found || status != TransferAcknowledged {
+		t.Fatalf("expected acknowledged in batched tx, got %q %q found=%v", status, ackErr, found)
+	}
+
+	timeout := &abcitypes.TxResponse{Events: []*abci.Event{packetEvent("timeout_packet", "channel-3", "11")}}
+	if status, _, found := transferOutcome([]*abcitypes.TxResponse{timeout}, "timeout_packet", p); !found || status != TransferTimedOut {
+		t.Fatalf("expected timed out, got %q found=%v", status, found)
+	}
+
+	other := &abcitypes.TxResponse{Events: []*abci.Event{packetEvent("acknowledge_packet", "channel-3", "12")}}
+	if _, _, found := transferOutcome([]*abcitypes.TxResponse{other}, "acknowledge_packet", p); found {
+		t.Fatalf("matched a different sequence")
+	}
+}
+
+func TestIBCDenom(t *testing.T) {
+	denom, err := IBCDenom("ulume", "transfer", "channel-0")
+	if err != nil {
+		t.Fatalf("ibc denom: %v", err)
+	}
+	if !strings.HasPrefix(denom, "ibc/") || len(denom) != len("ibc/")+64 {
+		t.Fatalf("unexpected ibc denom %q", denom)
+	}
+	if native, _ := IBCDenom("ulume"); native != "ulume" {
+		t.Fatalf("native denom changed: %q", native)
+	}
+	if _, err := IBCDenom("ulume", "transfer"); err == nil {
+		t.Fatalf("expected error for odd hops")
+	}
+}
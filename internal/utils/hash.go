@@ -1,6 +1,8 @@
 package utils
 
 import (
+	"io"
+
 	"lukechampine.com/blake3"
 )
 
@@ -13,3 +15,12 @@ func HashFile(data []byte) []byte {
 	}
 	return hasher.Sum(nil)
 }
+
+// HashReader computes the Blake3 hash of everything read from r
+func HashReader(r io.Reader) ([]byte, error) {
+	hasher := blake3.New(32, nil)
+	if _, err := io.Copy(hasher, r); err != nil {
+		return nil, err
+	}
+	return hasher.Sum(nil), nil
+}
@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	upgradetypes "cosmossdk.io/x/upgrade/types"
 	"github.com/LumeraProtocol/sdk-go/constants"
 	sdkethsecp256k1 "github.com/LumeraProtocol/sdk-go/pkg/crypto/ethsecp256k1"
 	"github.com/cosmos/cosmos-sdk/client"
@@ -20,8 +21,25 @@ import (
 	"github.com/cosmos/cosmos-sdk/std"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	authtx "github.com/cosmos/cosmos-sdk/x/auth/tx"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	vestingtypes "github.com/cosmos/cosmos-sdk/x/auth/vesting/types"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	distrtypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	govv1beta1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1beta1"
+	slashingtypes "github.com/cosmos/cosmos-sdk/x/slashing/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	icacontrollertypes "github.com/cosmos/ibc-go/v10/modules/apps/27-interchain-accounts/controller/types"
+	icahosttypes "github.com/cosmos/ibc-go/v10/modules/apps/27-interchain-accounts/host/types"
+	transfertypes "github.com/cosmos/ibc-go/v10/modules/apps/transfer/types"
+	ibccoretypes "github.com/cosmos/ibc-go/v10/modules/core/types"
+	ibctm "github.com/cosmos/ibc-go/v10/modules/light-clients/07-tendermint"
 
 	actiontypes "github.com/LumeraProtocol/lumera/x/action/v1/types"
+	audittypes "github.com/LumeraProtocol/lumera/x/audit/v1/types"
+	claimtypes "github.com/LumeraProtocol/lumera/x/claim/types"
+	supernodetypes "github.com/LumeraProtocol/lumera/x/supernode/v1/types"
 )
 
 const (
@@ -251,17 +269,41 @@ func ImportKey(kr keyring.Keyring, keyName, mnemonicFile, hrp string, keyType Ke
 	return pub.Bytes(), addr, nil
 }
 
-// NewDefaultTxConfig constructs a client.TxConfig backed by a protobuf codec,
-// registering Lumera action message interfaces as required for signing/encoding.
+// NewDefaultTxConfig constructs a client.TxConfig backed by a protobuf codec
+// over NewInterfaceRegistry, as required for signing/encoding.
 func NewDefaultTxConfig() client.TxConfig {
+	proto := codec.NewProtoCodec(NewInterfaceRegistry())
+	return authtx.NewTxConfig(proto, authtx.DefaultSignModes)
+}
+
+// NewInterfaceRegistry returns a registry holding the crypto, Cosmos SDK, IBC
+// and Lumera module interfaces found in Lumera transactions.
+func NewInterfaceRegistry() codectypes.InterfaceRegistry {
 	reg := codectypes.NewInterfaceRegistry()
 	// Register crypto and module interfaces
 	cryptocodec.RegisterInterfaces(reg)
 	sdkethsecp256k1.RegisterInterfaces(reg)
+	std.RegisterInterfaces(reg)
+	authtypes.RegisterInterfaces(reg)
+	vestingtypes.RegisterInterfaces(reg)
+	banktypes.RegisterInterfaces(reg)
+	stakingtypes.RegisterInterfaces(reg)
+	distrtypes.RegisterInterfaces(reg)
+	slashingtypes.RegisterInterfaces(reg)
+	upgradetypes.RegisterInterfaces(reg)
+	govv1.RegisterInterfaces(reg)
+	govv1beta1.RegisterInterfaces(reg)
+	authz.RegisterInterfaces(reg)
+	ibccoretypes.RegisterInterfaces(reg)
+	ibctm.RegisterInterfaces(reg)
+	transfertypes.RegisterInterfaces(reg)
+	icacontrollertypes.RegisterInterfaces(reg)
+	icahosttypes.RegisterInterfaces(reg)
 	actiontypes.RegisterInterfaces(reg)
-
-	proto := codec.NewProtoCodec(reg)
-	return authtx.NewTxConfig(proto, authtx.DefaultSignModes)
+	supernodetypes.RegisterInterfaces(reg)
+	claimtypes.RegisterInterfaces(reg)
+	audittypes.RegisterInterfaces(reg)
+	return reg
 }
 
 func readMnemonicFile(mnemonicFile string) (string, error) {
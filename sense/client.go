@@ -0,0 +1,73 @@
+// Package sense implements the SENSE action workflow on chain: building
+// request metadata for an image, registering the action, waiting for
+// finalization and parsing the duplicate-detection and fingerprint results.
+//
+// Handing the image to the supernodes and fetching the results back is not
+// part of the public API yet: SuperNode SDK v2 has no sense service. The
+// supernode-side steps in transport.go are kept unexported until it does.
+package sense
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	actiontypes "github.com/LumeraProtocol/lumera/x/action/v1/types"
+	"go.uber.org/zap"
+
+	"github.com/LumeraProtocol/sdk-go/blockchain"
+	"github.com/LumeraProtocol/sdk-go/types"
+)
+
+// Config for a sense client
+type Config struct {
+	// PollInterval controls how often action state is polled while waiting (default 5s).
+	PollInterval time.Duration
+	// Logger is optional; when set, operations emit diagnostics.
+	Logger *zap.Logger
+}
+
+// actionQuerier is the part of blockchain.ActionClient used by the sense client.
+type actionQuerier interface {
+	Params(ctx context.Context) (*actiontypes.Params, error)
+	GetActionFee(ctx context.Context, dataSize int64) (string, error)
+	GetAction(ctx context.Context, actionID string) (*types.Action, error)
+}
+
+// requestSender is the part of blockchain.Client that registers actions.
+type requestSender interface {
+	RequestActionTx(ctx context.Context, creator string, actionType actiontypes.ActionType, metadata, price, expiration string, fileSizeKbs int64, memo string) (*types.ActionResult, error)
+}
+
+// Client provides access to sense operations
+type Client struct {
+	actions actionQuerier
+	sender  requestSender
+	config  Config
+
+	// transport reaches the supernodes; nil until they expose a sense service.
+	transport transport
+}
+
+// New creates a new sense client on top of a blockchain client.
+func New(cfg Config, bc *blockchain.Client) (*Client, error) {
+	if bc == nil {
+		return nil, fmt.Errorf("blockchain client is required")
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = 5 * time.Second
+	}
+	return &Client{actions: bc.Action, sender: bc, config: cfg}, nil
+}
+
+// SetLogger configures optional diagnostics logging.
+func (c *Client) SetLogger(logger *zap.Logger) {
+	c.config.Logger = logger
+}
+
+func (c *Client) logf(format string, args ...interface{}) {
+	if c.config.Logger == nil {
+		return
+	}
+	c.config.Logger.Info(fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,195 @@
+package sense
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	actiontypes "github.com/LumeraProtocol/lumera/x/action/v1/types"
+
+	"github.com/LumeraProtocol/sdk-go/blockchain"
+	"github.com/LumeraProtocol/sdk-go/internal/utils"
+	"github.com/LumeraProtocol/sdk-go/types"
+)
+
+// RequestOptions configures a sense request
+type RequestOptions struct {
+	CollectionID string // optional collection the image belongs to
+	GroupID      string // optional OpenAPI group ID
+	Memo         string // optional tx memo
+}
+
+// RequestOption is a functional option for sense requests
+type RequestOption func(*RequestOptions)
+
+// WithCollectionID sets the collection ID
+func WithCollectionID(id string) RequestOption {
+	return func(o *RequestOptions) {
+		o.CollectionID = id
+	}
+}
+
+// WithGroupID sets the group ID
+func WithGroupID(id string) RequestOption {
+	return func(o *RequestOptions) {
+		o.GroupID = id
+	}
+}
+
+// WithMemo sets the tx memo
+func WithMemo(memo string) RequestOption {
+	return func(o *RequestOptions) {
+		o.Memo = memo
+	}
+}
+
+// CreateRequestActionMessage builds sense metadata for the image at imagePath,
+// resolves the fee and expiration from chain params and constructs a
+// MsgRequestAction without broadcasting it.
+func (c *Client) CreateRequestActionMessage(ctx context.Context, creator, imagePath string, options *RequestOptions) (*actiontypes.MsgRequestAction, error) {
+	if creator == "" {
+		return nil, fmt.Errorf("creator is required")
+	}
+	if options == nil {
+		options = &RequestOptions{}
+	}
+	f, err := os.Open(imagePath)
+	if err != nil {
+		return nil, fmt.Errorf("open image: %w", err)
+	}
+	defer f.Close() //nolint:errcheck
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("stat image: %w", err)
+	}
+	if err := checkImage(f); err != nil {
+		return nil, err
+	}
+	sum, err := utils.HashReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("hash image: %w", err)
+	}
+	dataHash := base64.StdEncoding.EncodeToString(sum)
+
+	params, err := c.actions.Params(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ic, err := randomIC()
+	if err != nil {
+		return nil, err
+	}
+	meta := &actiontypes.SenseMetadata{
+		DataHash:            dataHash,
+		DdAndFingerprintsIc: ic,
+		CollectionId:        options.CollectionID,
+		GroupId:             options.GroupID,
+	}
+	metaBytes, err := json.Marshal(meta)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+
+	sizeKB := (info.Size() + 1023) / 1024
+	amount, err := c.actions.GetActionFee(ctx, sizeKB)
+	if err != nil {
+		return nil, err
+	}
+	price := amount + params.BaseActionFee.Denom
+	// Chain duration plus a 1h buffer, matching the cascade metadata builder.
+	expiration := strconv.FormatInt(time.Now().Add(params.ExpirationDuration).Add(time.Hour).Unix(), 10)
+
+	c.logf("sense: built metadata for %s (price=%s expires=%s)", imagePath, price, expiration)
+	return blockchain.NewMsgRequestAction(creator, actiontypes.ActionTypeSense, string(metaBytes), price, expiration, sizeKB), nil
+}
+
+// SendRequestActionMessage signs, simulates and broadcasts the provided request message.
+func (c *Client) SendRequestActionMessage(ctx context.Context, msg *actiontypes.MsgRequestAction, memo string) (*types.ActionResult, error) {
+	if msg == nil {
+		return nil, fmt.Errorf("msg is required")
+	}
+	if msg.ActionType != actiontypes.ActionTypeSense.String() {
+		return nil, fmt.Errorf("msg action type is %s, expected %s", msg.ActionType, actiontypes.ActionTypeSense)
+	}
+	sizeKB, _ := strconv.ParseInt(msg.FileSizeKbs, 10, 64)
+	ar, err := c.sender.RequestActionTx(ctx, msg.Creator, actiontypes.ActionTypeSense, msg.Metadata, msg.Price, msg.ExpirationTime, sizeKB, memo)
+	if err != nil {
+		return nil, err
+	}
+	c.logf("sense: request action confirmed action_id=%s height=%d tx=%s", ar.ActionID, ar.Height, ar.TxHash)
+	return ar, nil
+}
+
+// Request builds and broadcasts a sense MsgRequestAction for the image.
+func (c *Client) Request(ctx context.Context, creator, imagePath string, opts ...RequestOption) (*types.ActionResult, error) {
+	options := &RequestOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	msg, err := c.CreateRequestActionMessage(ctx, creator, imagePath, options)
+	if err != nil {
+		return nil, err
+	}
+	ar, err := c.SendRequestActionMessage(ctx, msg, options.Memo)
+	if err != nil {
+		return nil, fmt.Errorf("request action tx: %w", err)
+	}
+	return ar, nil
+}
+
+// WaitForFinalization waits until the action reaches DONE (or APPROVED).
+// FAILED, REJECTED and EXPIRED states end the wait with an error.
+func (c *Client) WaitForFinalization(ctx context.Context, actionID string) (*types.Action, error) {
+	ticker := time.NewTicker(c.config.PollInterval)
+	defer ticker.Stop()
+	for {
+		action, err := c.actions.GetAction(ctx, actionID)
+		if err == nil && action != nil {
+			switch action.State {
+			case types.ActionStateDone, types.ActionStateApproved:
+				return action, nil
+			case types.ActionStateFailed, types.ActionStateRejected, types.ActionStateExpired:
+				return nil, fmt.Errorf("%w: action %s ended in state %s", types.ErrTaskFailed, actionID, action.State)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("wait for action %s finalization: %w", actionID, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+func checkImage(f *os.File) error {
+	head := make([]byte, 512)
+	n, err := f.Read(head)
+	if err != nil && err != io.EOF {
+		return fmt.Errorf("read image: %w", err)
+	}
+	if ct := http.DetectContentType(head[:n]); !strings.HasPrefix(ct, "image/") {
+		return fmt.Errorf("file is not an image (detected %s)", ct)
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("rewind image: %w", err)
+	}
+	return nil
+}
+
+// randomIC picks the initial dd-and-fingerprints counter in [1,100].
+func randomIC() (uint64, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(100))
+	if err != nil {
+		return 0, fmt.Errorf("generate counter: %w", err)
+	}
+	return n.Uint64() + 1, nil
+}
@@ -0,0 +1,125 @@
+package sense
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	actiontypes "github.com/LumeraProtocol/lumera/x/action/v1/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	"lukechampine.com/blake3"
+
+	"github.com/LumeraProtocol/sdk-go/types"
+)
+
+// fakeChain serves action queries and records registrations. GetAction
+// walks through states, repeating the last one.
+type fakeChain struct {
+	mu       sync.Mutex
+	states   []types.ActionState
+	meta     *types.SenseMetadata
+	polls    int
+	feeSize  int64
+	requests []string
+}
+
+func (f *fakeChain) Params(context.Context) (*actiontypes.Params, error) {
+	return &actiontypes.Params{BaseActionFee: sdk.NewInt64Coin("ulume", 10), ExpirationDuration: 24 * time.Hour}, nil
+}
+
+func (f *fakeChain) GetActionFee(_ context.Context, dataSize int64) (string, error) {
+	f.feeSize = dataSize
+	return "1500", nil
+}
+
+func (f *fakeChain) GetAction(_ context.Context, actionID string) (*types.Action, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	state := f.states[min(f.polls, len(f.states)-1)]
+	f.polls++
+	return &types.Action{ID: actionID, Type: types.ActionTypeSense, State: state, Metadata: f.meta}, nil
+}
+
+func (f *fakeChain) RequestActionTx(_ context.Context, creator string, actionType actiontypes.ActionType, metadata, price, _ string, _ int64, memo string) (*types.ActionResult, error) {
+	f.requests = append(f.requests, creator+" "+actionType.String()+" "+price+" "+memo)
+	return &types.ActionResult{ActionID: "7", TxHash: "TX", Height: 12}, nil
+}
+
+func newTestClient(chain *fakeChain) *Client {
+	return &Client{actions: chain, sender: chain, config: Config{PollInterval: time.Millisecond}}
+}
+
+func writeImage(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "image.png")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	require.NoError(t, png.Encode(f, image.NewGray(image.Rect(0, 0, 64, 64))))
+	require.NoError(t, f.Close())
+	return path
+}
+
+func TestCreateRequestActionMessage(t *testing.T) {
+	chain := &fakeChain{}
+	c := newTestClient(chain)
+	path := writeImage(t)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	start := time.Now()
+	msg, err := c.CreateRequestActionMessage(context.Background(), "lumera1creator", path, &RequestOptions{CollectionID: "col", GroupID: "grp"})
+	require.NoError(t, err)
+	require.Equal(t, "lumera1creator", msg.Creator)
+	require.Equal(t, actiontypes.ActionTypeSense.String(), msg.ActionType)
+	require.Equal(t, "1500ulume", msg.Price)
+	require.Equal(t, int64((len(data)+1023)/1024), chain.feeSize)
+	require.Equal(t, strconv.FormatInt(chain.feeSize, 10), msg.FileSizeKbs)
+
+	// Chain expiration duration plus the 1h buffer.
+	exp, err := strconv.ParseInt(msg.ExpirationTime, 10, 64)
+	require.NoError(t, err)
+	require.InDelta(t, start.Add(25*time.Hour).Unix(), exp, 5)
+
+	var meta actiontypes.SenseMetadata
+	require.NoError(t, json.Unmarshal([]byte(msg.Metadata), &meta))
+	sum := blake3.Sum256(data)
+	require.Equal(t, base64.StdEncoding.EncodeToString(sum[:]), meta.DataHash)
+	require.Equal(t, "col", meta.CollectionId)
+	require.Equal(t, "grp", meta.GroupId)
+	require.GreaterOrEqual(t, meta.DdAndFingerprintsIc, uint64(1))
+	require.LessOrEqual(t, meta.DdAndFingerprintsIc, uint64(100))
+
+	notImage := filepath.Join(t.TempDir(), "notes.txt")
+	require.NoError(t, os.WriteFile(notImage, []byte("plain text"), 0o600))
+	_, err = c.CreateRequestActionMessage(context.Background(), "lumera1creator", notImage, nil)
+	require.ErrorContains(t, err, "not an image")
+	_, err = c.CreateRequestActionMessage(context.Background(), "", path, nil)
+	require.Error(t, err)
+}
+
+func TestWaitForFinalization(t *testing.T) {
+	chain := &fakeChain{states: []types.ActionState{types.ActionStatePending, types.ActionStateProcessing, types.ActionStateDone}}
+	action, err := newTestClient(chain).WaitForFinalization(context.Background(), "7")
+	require.NoError(t, err)
+	require.Equal(t, types.ActionStateDone, action.State)
+	require.Equal(t, 3, chain.polls)
+
+	chain = &fakeChain{states: []types.ActionState{types.ActionStatePending, types.ActionStateRejected}}
+	_, err = newTestClient(chain).WaitForFinalization(context.Background(), "7")
+	require.ErrorIs(t, err, types.ErrTaskFailed)
+
+	chain = &fakeChain{states: []types.ActionState{types.ActionStatePending}}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = newTestClient(chain).WaitForFinalization(ctx, "7")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -0,0 +1,119 @@
+package sense
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// zstdMagic prefixes zstd frames.
+var zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+
+// Result holds the duplicate-detection and fingerprint output for an image.
+type Result struct {
+	Block                      string `json:"block"`
+	Principal                  string `json:"principal"`
+	DupeDetectionSystemVersion string `json:"dupe_detection_system_version"`
+	HashOfCandidateImageFile   string `json:"hash_of_candidate_image_file"`
+
+	IsLikelyDupe         bool    `json:"is_likely_dupe"`
+	IsRareOnInternet     bool    `json:"is_rare_on_internet"`
+	OverallRarenessScore float64 `json:"overall_rareness_score"`
+
+	PctOfTop10MostSimilarWithDupeProbAbove25pct float64 `json:"pct_of_top_10_most_similar_with_dupe_prob_above_25pct"`
+	PctOfTop10MostSimilarWithDupeProbAbove33pct float64 `json:"pct_of_top_10_most_similar_with_dupe_prob_above_33pct"`
+	PctOfTop10MostSimilarWithDupeProbAbove50pct float64 `json:"pct_of_top_10_most_similar_with_dupe_prob_above_50pct"`
+
+	RarenessScoresTableJSONCompressedB64 string `json:"rareness_scores_table_json_compressed_b64"`
+
+	InternetRareness      *InternetRareness      `json:"internet_rareness,omitempty"`
+	OpenNSFWScore         float64                `json:"open_nsfw_score"`
+	AlternativeNSFWScores *AlternativeNSFWScores `json:"alternative_nsfw_scores,omitempty"`
+
+	ImageFingerprintOfCandidateImageFile []float64 `json:"image_fingerprint_of_candidate_image_file"`
+
+	CollectionName                    string  `json:"collection_name_string,omitempty"`
+	OpenAPIGroupID                    string  `json:"open_api_group_id_string,omitempty"`
+	GroupRarenessScore                float64 `json:"group_rareness_score"`
+	SimilarityScoreToFirstEntry       float64 `json:"similarity_score_to_first_entry_in_collection"`
+	CPProbability                     float64 `json:"cp_probability"`
+	ChildProbability                  float64 `json:"child_probability"`
+	IsInvalidSense                    bool    `json:"is_invalid_sense_request"`
+	InvalidSenseReason                string  `json:"invalid_sense_request_reason,omitempty"`
+	CandidateImageThumbnailWebpBase64 string  `json:"candidate_image_thumbnail_webp_as_base64_string,omitempty"`
+
+	// Raw keeps the decoded JSON document for fields not modelled above.
+	Raw json.RawMessage `json:"-"`
+}
+
+// InternetRareness summarises reverse image search results.
+type InternetRareness struct {
+	RareOnInternetSummaryTableAsJSONCompressedB64    string `json:"rare_on_internet_summary_table_as_json_compressed_b64"`
+	RareOnInternetGraphJSONCompressedB64             string `json:"rare_on_internet_graph_json_compressed_b64"`
+	AlternativeRareOnInternetDictAsJSONCompressedB64 string `json:"alternative_rare_on_internet_dict_as_json_compressed_b64"`
+	MinNumberOfExactMatchesInPage                    uint32 `json:"min_number_of_exact_matches_in_page"`
+	EarliestAvailableDateOfInternetResults           string `json:"earliest_available_date_of_internet_results"`
+}
+
+// AlternativeNSFWScores holds per-category NSFW model scores.
+type AlternativeNSFWScores struct {
+	Drawings float64 `json:"drawings"`
+	Hentai   float64 `json:"hentai"`
+	Neutral  float64 `json:"neutral"`
+	Porn     float64 `json:"porn"`
+	Sexy     float64 `json:"sexy"`
+}
+
+// ParseResult decodes a dd-and-fingerprints file. The payload may be zstd
+// compressed and may be in the signed "base64(json).sig1.sig2.sig3[.counter]"
+// form stored by supernodes; plain JSON is accepted too.
+func ParseResult(data []byte) (*Result, error) {
+	doc, err := decodeResultDocument(data)
+	if err != nil {
+		return nil, err
+	}
+	var res Result
+	if err := json.Unmarshal(doc, &res); err != nil {
+		return nil, fmt.Errorf("decode sense result: %w", err)
+	}
+	res.Raw = append(json.RawMessage(nil), doc...)
+	return &res, nil
+}
+
+func decodeResultDocument(data []byte) ([]byte, error) {
+	for depth := 0; depth < 3; depth++ {
+		data = bytes.TrimSpace(data)
+		if len(data) == 0 {
+			return nil, fmt.Errorf("empty sense result")
+		}
+		switch {
+		case bytes.HasPrefix(data, zstdMagic):
+			dec, err := zstd.NewReader(nil)
+			if err != nil {
+				return nil, fmt.Errorf("init zstd: %w", err)
+			}
+			out, err := dec.DecodeAll(data, nil)
+			dec.Close()
+			if err != nil {
+				return nil, fmt.Errorf("decompress sense result: %w", err)
+			}
+			data = out
+		case data[0] == '{':
+			return data, nil
+		default:
+			head := data
+			if i := bytes.IndexByte(data, '.'); i >= 0 {
+				head = data[:i]
+			}
+			out, err := base64.StdEncoding.DecodeString(string(head))
+			if err != nil {
+				return nil, fmt.Errorf("decode sense result payload: %w", err)
+			}
+			data = out
+		}
+	}
+	return nil, fmt.Errorf("unrecognised sense result encoding")
+}
@@ -0,0 +1,59 @@
+package sense
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/require"
+)
+
+const sampleResult = `{
+	"block": "100",
+	"principal": "lumera1creator",
+	"dupe_detection_system_version": "1.0",
+	"is_likely_dupe": true,
+	"overall_rareness_score": 0.25,
+	"alternative_nsfw_scores": {"neutral": 0.9},
+	"image_fingerprint_of_candidate_image_file": [0.1, 0.2],
+	"extra_field": 7
+}`
+
+func TestParseResult(t *testing.T) {
+	check := func(t *testing.T, data []byte) {
+		t.Helper()
+		res, err := ParseResult(data)
+		require.NoError(t, err)
+		require.True(t, res.IsLikelyDupe)
+		require.Equal(t, "1.0", res.DupeDetectionSystemVersion)
+		require.Equal(t, 0.25, res.OverallRarenessScore)
+		require.Equal(t, []float64{0.1, 0.2}, res.ImageFingerprintOfCandidateImageFile)
+		require.NotNil(t, res.AlternativeNSFWScores)
+		require.Equal(t, 0.9, res.AlternativeNSFWScores.Neutral)
+		require.Contains(t, string(res.Raw), "extra_field")
+	}
+
+	t.Run("plain json", func(t *testing.T) {
+		check(t, []byte(sampleResult))
+	})
+
+	signed := base64.StdEncoding.EncodeToString([]byte(sampleResult)) + ".sig1.sig2.sig3.42"
+	t.Run("signed", func(t *testing.T) {
+		check(t, []byte(signed))
+	})
+
+	t.Run("zstd signed", func(t *testing.T) {
+		enc, err := zstd.NewWriter(nil)
+		require.NoError(t, err)
+		compressed := enc.EncodeAll([]byte(signed), nil)
+		require.NoError(t, enc.Close())
+		check(t, compressed)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := ParseResult([]byte("   "))
+		require.Error(t, err)
+		_, err = ParseResult([]byte("!!not-base64!!.sig"))
+		require.Error(t, err)
+	})
+}
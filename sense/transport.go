@@ -0,0 +1,129 @@
+package sense
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/LumeraProtocol/sdk-go/types"
+)
+
+// errTransportUnavailable is returned by the supernode-side steps while no
+// transport is configured.
+var errTransportUnavailable = errors.New("sense: supernode transport not available")
+
+// transport moves sense data between the SDK and the supernodes.
+type transport interface {
+	// Upload hands the image for actionID to the supernodes and returns a task ID.
+	Upload(ctx context.Context, actionID string, image io.Reader, size int64) (string, error)
+	// Fetch retrieves a stored dd-and-fingerprints file by its ID.
+	Fetch(ctx context.Context, id string) ([]byte, error)
+}
+
+func (c *Client) supernodes() (transport, error) {
+	if c.transport == nil {
+		return nil, errTransportUnavailable
+	}
+	return c.transport, nil
+}
+
+// upload hands the image for a registered action to the supernodes.
+func (c *Client) upload(ctx context.Context, actionID, imagePath string) (string, error) {
+	if actionID == "" || imagePath == "" {
+		return "", fmt.Errorf("actionID and imagePath are required")
+	}
+	t, err := c.supernodes()
+	if err != nil {
+		return "", err
+	}
+	f, err := os.Open(imagePath)
+	if err != nil {
+		return "", fmt.Errorf("open image: %w", err)
+	}
+	defer f.Close() //nolint:errcheck
+	info, err := f.Stat()
+	if err != nil {
+		return "", fmt.Errorf("stat image: %w", err)
+	}
+	taskID, err := t.Upload(ctx, actionID, f, info.Size())
+	if err != nil {
+		return "", fmt.Errorf("sense upload: %w", err)
+	}
+	c.logf("sense: upload started action_id=%s task_id=%s", actionID, taskID)
+	return taskID, nil
+}
+
+// results fetches and parses the dd-and-fingerprints results of a finalized
+// action. Each ID in the action metadata is tried until one parses.
+func (c *Client) results(ctx context.Context, actionID string) (*Result, error) {
+	t, err := c.supernodes()
+	if err != nil {
+		return nil, err
+	}
+	action, err := c.actions.GetAction(ctx, actionID)
+	if err != nil {
+		return nil, err
+	}
+	meta, ok := action.Metadata.(*types.SenseMetadata)
+	if !ok {
+		return nil, fmt.Errorf("action %s has no sense metadata", actionID)
+	}
+	if len(meta.DDAndFingerprintsIDs) == 0 {
+		return nil, fmt.Errorf("action %s is not finalized (no dd_and_fingerprints_ids)", actionID)
+	}
+
+	var errs []error
+	for _, id := range meta.DDAndFingerprintsIDs {
+		data, err := t.Fetch(ctx, id)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("fetch %s: %w", id, err))
+			continue
+		}
+		res, err := ParseResult(data)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("parse %s: %w", id, err))
+			continue
+		}
+		return res, nil
+	}
+	return nil, fmt.Errorf("no usable sense result for action %s: %w", actionID, errors.Join(errs...))
+}
+
+// processResult is the outcome of an end-to-end sense run.
+type processResult struct {
+	types.ActionResult
+	TaskID string
+	Result *Result
+}
+
+// process runs the whole workflow:
+//  1. Request (metadata, fee and MsgRequestAction)
+//  2. upload to supernodes
+//  3. WaitForFinalization
+//  4. results
+//
+// The transport is checked first so no fee is paid for an image that cannot
+// be uploaded.
+func (c *Client) process(ctx context.Context, creator, imagePath string, opts ...RequestOption) (*processResult, error) {
+	if _, err := c.supernodes(); err != nil {
+		return nil, err
+	}
+	ar, err := c.Request(ctx, creator, imagePath, opts...)
+	if err != nil {
+		return nil, err
+	}
+	taskID, err := c.upload(ctx, ar.ActionID, imagePath)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := c.WaitForFinalization(ctx, ar.ActionID); err != nil {
+		return nil, err
+	}
+	res, err := c.results(ctx, ar.ActionID)
+	if err != nil {
+		return nil, err
+	}
+	return &processResult{ActionResult: *ar, TaskID: taskID, Result: res}, nil
+}
@@ -0,0 +1,69 @@
+package sense
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/LumeraProtocol/sdk-go/types"
+)
+
+// fakeTransport serves files by ID and records uploads.
+type fakeTransport struct {
+	files    map[string][]byte
+	uploaded []byte
+}
+
+func (f *fakeTransport) Upload(_ context.Context, actionID string, image io.Reader, size int64) (string, error) {
+	data, err := io.ReadAll(image)
+	if err != nil {
+		return "", err
+	}
+	if int64(len(data)) != size {
+		return "", errors.New("size mismatch")
+	}
+	f.uploaded = data
+	return "task-" + actionID, nil
+}
+
+func (f *fakeTransport) Fetch(_ context.Context, id string) ([]byte, error) {
+	data, ok := f.files[id]
+	if !ok {
+		return nil, types.ErrNotFound
+	}
+	return data, nil
+}
+
+func TestProcess(t *testing.T) {
+	chain := &fakeChain{
+		states: []types.ActionState{types.ActionStatePending, types.ActionStateDone},
+		meta:   &types.SenseMetadata{DDAndFingerprintsIDs: []string{"missing", "broken", "dd-1"}},
+	}
+	c := newTestClient(chain)
+	path := writeImage(t)
+
+	_, err := c.process(context.Background(), "lumera1creator", path)
+	require.ErrorIs(t, err, errTransportUnavailable)
+	require.Empty(t, chain.requests, "no fee is paid without a transport")
+
+	tr := &fakeTransport{files: map[string][]byte{"broken": []byte("{"), "dd-1": []byte(sampleResult)}}
+	c.transport = tr
+	res, err := c.process(context.Background(), "lumera1creator", path, WithMemo("m"))
+	require.NoError(t, err)
+	require.Equal(t, []string{"lumera1creator ACTION_TYPE_SENSE 1500ulume m"}, chain.requests)
+	require.Equal(t, "7", res.ActionID)
+	require.Equal(t, "task-7", res.TaskID)
+	require.NotEmpty(t, tr.uploaded)
+	require.True(t, res.Result.IsLikelyDupe)
+
+	tr.files = nil
+	_, err = c.results(context.Background(), "7")
+	require.ErrorContains(t, err, "no usable sense result")
+	require.ErrorIs(t, err, types.ErrNotFound)
+
+	_, err = c.upload(context.Background(), "", path)
+	require.Error(t, err)
+}
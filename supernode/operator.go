@@ -0,0 +1,464 @@
+package supernode
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	supernodetypes "github.com/LumeraProtocol/lumera/x/supernode/v1/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/LumeraProtocol/sdk-go/blockchain"
+	"github.com/LumeraProtocol/sdk-go/constants"
+	"github.com/LumeraProtocol/sdk-go/types"
+)
+
+// DefaultGRPCPort is the supernode service port assumed when the registered
+// IP address carries no port.
+const DefaultGRPCPort = "4444"
+
+// Lifecycle is the desired on-chain lifecycle state of a supernode.
+type Lifecycle string
+
+const (
+	LifecycleActive       Lifecycle = "active"
+	LifecycleStopped      Lifecycle = "stopped"
+	LifecycleDeregistered Lifecycle = "deregistered"
+)
+
+// DesiredState describes how a supernode should be registered on chain.
+// Empty optional fields leave the on-chain value untouched.
+type DesiredState struct {
+	ValidatorAddress string
+	// IPAddress is a host or host:port the supernode serves on.
+	IPAddress        string
+	SupernodeAccount string
+	// P2PPort defaults to the chain default (4445) on registration.
+	P2PPort string
+	Note    string
+	// State defaults to LifecycleActive.
+	State Lifecycle
+	// StopReason is recorded when the supernode has to be stopped.
+	StopReason string
+}
+
+// Backend is the chain access an Operator needs. NewChainBackend adapts a
+// *blockchain.Client.
+type Backend interface {
+	GetSuperNode(ctx context.Context, validatorAddr string) (*types.SuperNode, error)
+	GetValidator(ctx context.Context, validatorAddr string) (*stakingtypes.Validator, error)
+	RegisterSupernodeTx(ctx context.Context, creator, validatorAddress, ipAddress, supernodeAccount, p2pPort, memo string) (*types.ActionResult, error)
+	DeregisterSupernodeTx(ctx context.Context, creator, validatorAddress, memo string) (*types.ActionResult, error)
+	StartSupernodeTx(ctx context.Context, creator, validatorAddress, memo string) (*types.ActionResult, error)
+	StopSupernodeTx(ctx context.Context, creator, validatorAddress, reason, memo string) (*types.ActionResult, error)
+	UpdateSupernodeTx(ctx context.Context, creator, validatorAddress, ipAddress, note, supernodeAccount, p2pPort, memo string) (*types.ActionResult, error)
+}
+
+type chainBackend struct {
+	*blockchain.Client
+	staking stakingtypes.QueryClient
+}
+
+// NewChainBackend adapts a blockchain client to the operator Backend.
+func NewChainBackend(bc *blockchain.Client) Backend {
+	return &chainBackend{Client: bc, staking: stakingtypes.NewQueryClient(bc.GRPCConn())}
+}
+
+func (b *chainBackend) GetSuperNode(ctx context.Context, validatorAddr string) (*types.SuperNode, error) {
+	return b.SuperNode.GetSuperNode(ctx, validatorAddr)
+}
+
+func (b *chainBackend) GetValidator(ctx context.Context, validatorAddr string) (*stakingtypes.Validator, error) {
+	resp, err := b.staking.Validator(ctx, &stakingtypes.QueryValidatorRequest{ValidatorAddr: validatorAddr})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get validator: %w", err)
+	}
+	return &resp.Validator, nil
+}
+
+// OperatorConfig configures an Operator.
+type OperatorConfig struct {
+	// Memo is attached to every transaction.
+	Memo string
+	// DialTimeout bounds reachability checks (default 3s).
+	DialTimeout time.Duration
+	// SkipReachability disables the TCP checks of the service and P2P ports.
+	SkipReachability bool
+	// Logger is optional; when set, planned steps are logged.
+	Logger *zap.Logger
+}
+
+// Operator manages the on-chain lifecycle of a validator's supernode.
+type Operator struct {
+	backend Backend
+	creator string
+	cfg     OperatorConfig
+	dial    func(ctx context.Context, network, addr string) (net.Conn, error)
+}
+
+// NewOperator creates an operator that signs as creator, which must be the
+// validator operator account.
+func NewOperator(backend Backend, creator string, cfg OperatorConfig) (*Operator, error) {
+	if backend == nil {
+		return nil, fmt.Errorf("backend is required")
+	}
+	if _, err := decodeBech32(creator, constants.LumeraAccountHRP); err != nil {
+		return nil, fmt.Errorf("invalid creator: %w", err)
+	}
+	if cfg.DialTimeout <= 0 {
+		cfg.DialTimeout = 3 * time.Second
+	}
+	d := &net.Dialer{}
+	return &Operator{backend: backend, creator: creator, cfg: cfg, dial: d.DialContext}, nil
+}
+
+// StepKind identifies a lifecycle transaction.
+type StepKind string
+
+const (
+	StepRegister   StepKind = "register"
+	StepUpdate     StepKind = "update"
+	StepStart      StepKind = "start"
+	StepStop       StepKind = "stop"
+	StepDeregister StepKind = "deregister"
+)
+
+// Step is a single transaction in a reconcile plan.
+type Step struct {
+	Kind StepKind
+	// Changes describes what the step changes, e.g. "ip_address: a -> b".
+	Changes []string
+
+	ipAddress        string
+	supernodeAccount string
+	p2pPort          string
+	note             string
+	reason           string
+}
+
+// Plan is the ordered set of steps needed to reach the desired state.
+type Plan struct {
+	Desired DesiredState
+	// Current is the on-chain record, nil when the supernode is not registered.
+	Current *types.SuperNode
+	Steps   []Step
+}
+
+// Empty reports whether the chain already matches the desired state.
+func (p *Plan) Empty() bool { return len(p.Steps) == 0 }
+
+// ReconcileResult reports the plan and the transactions it produced.
+type ReconcileResult struct {
+	Plan    *Plan
+	Results []*types.ActionResult
+}
+
+// Preflight validates the desired state: address formats, that the creator
+// operates the validator, that the validator exists, is bonded and not
+// jailed, IP/port syntax and (unless disabled) that the ports are reachable.
+func (o *Operator) Preflight(ctx context.Context, desired DesiredState) error {
+	desired = withDefaults(desired)
+	valBz, err := decodeBech32(desired.ValidatorAddress, constants.LumeraValidatorHRP)
+	if err != nil {
+		return fmt.Errorf("invalid validator address: %w", err)
+	}
+	creatorBz, _ := decodeBech32(o.creator, constants.LumeraAccountHRP)
+	if !bytes.Equal(valBz, creatorBz) {
+		return fmt.Errorf("creator %s is not the operator account of validator %s", o.creator, desired.ValidatorAddress)
+	}
+	switch desired.State {
+	case LifecycleActive, LifecycleStopped, LifecycleDeregistered:
+	default:
+		return fmt.Errorf("unknown desired state %q", desired.State)
+	}
+	if desired.State == LifecycleDeregistered {
+		return nil
+	}
+
+	if desired.SupernodeAccount != "" {
+		if _, err := decodeBech32(desired.SupernodeAccount, constants.LumeraAccountHRP); err != nil {
+			return fmt.Errorf("invalid supernode account: %w", err)
+		}
+	}
+	if desired.IPAddress != "" {
+		if _, _, err := splitHostPort(desired.IPAddress); err != nil {
+			return err
+		}
+	}
+	if desired.P2PPort != "" {
+		if err := validatePort(desired.P2PPort); err != nil {
+			return fmt.Errorf("invalid p2p port: %w", err)
+		}
+	}
+
+	val, err := o.backend.GetValidator(ctx, desired.ValidatorAddress)
+	if err != nil {
+		return fmt.Errorf("validator %s: %w", desired.ValidatorAddress, err)
+	}
+	if val.Jailed {
+		return fmt.Errorf("validator %s is jailed", desired.ValidatorAddress)
+	}
+	if val.Status != stakingtypes.Bonded {
+		return fmt.Errorf("validator %s is not bonded (status %s)", desired.ValidatorAddress, val.Status)
+	}
+
+	if desired.State == LifecycleActive && !o.cfg.SkipReachability && desired.IPAddress != "" {
+		host, port, _ := splitHostPort(desired.IPAddress)
+		if port == "" {
+			port = DefaultGRPCPort
+		}
+		if err := o.checkReachable(ctx, host, port); err != nil {
+			return err
+		}
+		p2p := desired.P2PPort
+		if p2p == "" {
+			p2p = supernodetypes.DefaultP2PPort
+		}
+		if err := o.checkReachable(ctx, host, p2p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Plan runs Preflight and computes the transactions needed to move the
+// on-chain record to the desired state without sending them.
+func (o *Operator) Plan(ctx context.Context, desired DesiredState) (*Plan, error) {
+	desired = withDefaults(desired)
+	if err := o.Preflight(ctx, desired); err != nil {
+		return nil, err
+	}
+	current, err := o.backend.GetSuperNode(ctx, desired.ValidatorAddress)
+	if err != nil {
+		// Custom backends may pass the chain's gRPC NotFound status through as is.
+		if !errors.Is(err, types.ErrNotFound) && status.Code(err) != codes.NotFound {
+			return nil, err
+		}
+		current = nil
+	}
+	steps, err := planSteps(current, desired)
+	if err != nil {
+		return nil, err
+	}
+	return &Plan{Desired: desired, Current: current, Steps: steps}, nil
+}
+
+// Reconcile plans and sends only the transactions needed to reach the desired
+// state. On failure the returned result holds the transactions that succeeded.
+func (o *Operator) Reconcile(ctx context.Context, desired DesiredState) (*ReconcileResult, error) {
+	plan, err := o.Plan(ctx, desired)
+	if err != nil {
+		return nil, err
+	}
+	res := &ReconcileResult{Plan: plan}
+	val := plan.Desired.ValidatorAddress
+	for _, step := range plan.Steps {
+		if o.cfg.Logger != nil {
+			o.cfg.Logger.Info("supernode reconcile step", zap.String("validator", val), zap.String("step", string(step.Kind)), zap.Strings("changes", step.Changes))
+		}
+		var ar *types.ActionResult
+		switch step.Kind {
+		case StepRegister:
+			ar, err = o.backend.RegisterSupernodeTx(ctx, o.creator, val, step.ipAddress, step.supernodeAccount, step.p2pPort, o.cfg.Memo)
+		case StepUpdate:
+			ar, err = o.backend.UpdateSupernodeTx(ctx, o.creator, val, step.ipAddress, step.note, step.supernodeAccount, step.p2pPort, o.cfg.Memo)
+		case StepStart:
+			ar, err = o.backend.StartSupernodeTx(ctx, o.creator, val, o.cfg.Memo)
+		case StepStop:
+			ar, err = o.backend.StopSupernodeTx(ctx, o.creator, val, step.reason, o.cfg.Memo)
+		case StepDeregister:
+			ar, err = o.backend.DeregisterSupernodeTx(ctx, o.creator, val, o.cfg.Memo)
+		}
+		if err != nil {
+			return res, fmt.Errorf("%s supernode: %w", step.Kind, err)
+		}
+		res.Results = append(res.Results, ar)
+	}
+	return res, nil
+}
+
+func withDefaults(d DesiredState) DesiredState {
+	if d.State == "" {
+		d.State = LifecycleActive
+	}
+	d.IPAddress = strings.TrimSpace(d.IPAddress)
+	d.P2PPort = strings.TrimSpace(d.P2PPort)
+	return d
+}
+
+var (
+	stateActive   = supernodetypes.SuperNodeStateActive.String()
+	stateStopped  = supernodetypes.SuperNodeStateStopped.String()
+	stateDisabled = supernodetypes.SuperNodeStateDisabled.String()
+)
+
+func planSteps(current *types.SuperNode, desired DesiredState) ([]Step, error) {
+	if desired.State == LifecycleDeregistered {
+		if current == nil || current.State == stateDisabled {
+			return nil, nil
+		}
+		return []Step{{Kind: StepDeregister, Changes: []string{fmt.Sprintf("state: %s -> %s", current.State, stateDisabled)}}}, nil
+	}
+
+	var steps []Step
+	state := ""
+	if current != nil {
+		state = current.State
+	}
+
+	switch {
+	case current == nil:
+		if desired.IPAddress == "" || desired.SupernodeAccount == "" {
+			return nil, fmt.Errorf("ip address and supernode account are required to register a supernode")
+		}
+		steps = append(steps, Step{
+			Kind:             StepRegister,
+			Changes:          []string{"register " + desired.IPAddress},
+			ipAddress:        desired.IPAddress,
+			supernodeAccount: desired.SupernodeAccount,
+			p2pPort:          desired.P2PPort,
+		})
+		state = stateActive
+		// Registration sets everything but the note.
+		current = &types.SuperNode{IPAddress: desired.IPAddress, SupernodeAccount: desired.SupernodeAccount, P2PPort: desired.P2PPort}
+	case state == stateDisabled:
+		// Re-registration only reactivates the existing record, so field
+		// changes still follow as an update. The message must carry valid
+		// fields regardless.
+		step := Step{
+			Kind:             StepRegister,
+			Changes:          []string{fmt.Sprintf("state: %s -> %s", state, stateActive)},
+			ipAddress:        firstNonEmpty(desired.IPAddress, current.IPAddress),
+			supernodeAccount: firstNonEmpty(desired.SupernodeAccount, current.SupernodeAccount),
+			p2pPort:          firstNonEmpty(desired.P2PPort, current.P2PPort),
+		}
+		if step.ipAddress == "" {
+			return nil, fmt.Errorf("ip address is required to re-register supernode %s", desired.ValidatorAddress)
+		}
+		steps = append(steps, step)
+		state = stateActive
+	}
+
+	if upd, ok := updateStep(current, desired); ok {
+		steps = append(steps, upd)
+	}
+
+	switch desired.State {
+	case LifecycleActive:
+		switch state {
+		case stateActive:
+		case stateStopped:
+			steps = append(steps, Step{Kind: StepStart, Changes: []string{fmt.Sprintf("state: %s -> %s", state, stateActive)}})
+		default:
+			return nil, fmt.Errorf("supernode %s is %s and cannot be started", desired.ValidatorAddress, state)
+		}
+	case LifecycleStopped:
+		if state != stateStopped {
+			steps = append(steps, Step{Kind: StepStop, Changes: []string{fmt.Sprintf("state: %s -> %s", state, stateStopped)}, reason: desired.StopReason})
+		}
+	}
+	return steps, nil
+}
+
+// updateStep returns a MsgUpdateSupernode step carrying only changed fields.
+func updateStep(current *types.SuperNode, desired DesiredState) (Step, bool) {
+	step := Step{Kind: StepUpdate}
+	if desired.IPAddress != "" && desired.IPAddress != current.IPAddress {
+		step.ipAddress = desired.IPAddress
+		step.Changes = append(step.Changes, fmt.Sprintf("ip_address: %q -> %q", current.IPAddress, desired.IPAddress))
+	}
+	if desired.SupernodeAccount != "" && desired.SupernodeAccount != current.SupernodeAccount {
+		step.supernodeAccount = desired.SupernodeAccount
+		step.Changes = append(step.Changes, fmt.Sprintf("supernode_account: %q -> %q", current.SupernodeAccount, desired.SupernodeAccount))
+	}
+	if desired.P2PPort != "" && desired.P2PPort != current.P2PPort {
+		step.p2pPort = desired.P2PPort
+		step.Changes = append(step.Changes, fmt.Sprintf("p2p_port: %q -> %q", current.P2PPort, desired.P2PPort))
+	}
+	if desired.Note != "" && desired.Note != current.Note {
+		step.note = desired.Note
+		step.Changes = append(step.Changes, fmt.Sprintf("note: %q -> %q", current.Note, desired.Note))
+	}
+	return step, len(step.Changes) > 0
+}
+
+func (o *Operator) checkReachable(ctx context.Context, host, port string) error {
+	addr := net.JoinHostPort(host, port)
+	dialCtx, cancel := context.WithTimeout(ctx, o.cfg.DialTimeout)
+	defer cancel()
+	conn, err := o.dial(dialCtx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("%s is not reachable: %w", addr, err)
+	}
+	_ = conn.Close()
+	return nil
+}
+
+// splitHostPort accepts "host" or "host:port" and validates both parts.
+func splitHostPort(addr string) (string, string, error) {
+	host, port := addr, ""
+	if h, p, err := net.SplitHostPort(addr); err == nil {
+		host, port = h, p
+		if err := validatePort(port); err != nil {
+			return "", "", fmt.Errorf("invalid ip address %q: %w", addr, err)
+		}
+	}
+	if net.ParseIP(host) == nil && !validHostname(host) {
+		return "", "", fmt.Errorf("invalid ip address %q", addr)
+	}
+	return host, port, nil
+}
+
+func validatePort(port string) error {
+	n, err := strconv.Atoi(port)
+	if err != nil || n < 1 || n > 65535 {
+		return fmt.Errorf("port %q must be a number between 1 and 65535", port)
+	}
+	return nil
+}
+
+func validHostname(host string) bool {
+	if host == "" || len(host) > 253 {
+		return false
+	}
+	for _, label := range strings.Split(host, ".") {
+		if label == "" || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for _, r := range label {
+			if !(r == '-' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z') {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func decodeBech32(addr, hrp string) ([]byte, error) {
+	prefix, bz, err := bech32.DecodeAndConvert(addr)
+	if err != nil {
+		return nil, err
+	}
+	if prefix != hrp {
+		return nil, fmt.Errorf("address %s has prefix %q, expected %q", addr, prefix, hrp)
+	}
+	return bz, nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,224 @@
+package supernode
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	supernodetypes "github.com/LumeraProtocol/lumera/x/supernode/v1/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/LumeraProtocol/sdk-go/types"
+)
+
+type fakeBackend struct {
+	sn        *types.SuperNode
+	notFound  error // returned when sn is nil; defaults to types.ErrNotFound
+	validator *stakingtypes.Validator
+	calls     []string
+	register  []string
+	update    []string
+}
+
+func (f *fakeBackend) GetSuperNode(context.Context, string) (*types.SuperNode, error) {
+	if f.sn == nil {
+		if f.notFound != nil {
+			return nil, f.notFound
+		}
+		return nil, types.ErrNotFound
+	}
+	return f.sn, nil
+}
+
+func (f *fakeBackend) GetValidator(context.Context, string) (*stakingtypes.Validator, error) {
+	if f.validator == nil {
+		return nil, errors.New("validator not found")
+	}
+	return f.validator, nil
+}
+
+func (f *fakeBackend) RegisterSupernodeTx(_ context.Context, _, _, ip, account, p2p, _ string) (*types.ActionResult, error) {
+	f.calls = append(f.calls, "register")
+	f.register = []string{ip, account, p2p}
+	return &types.ActionResult{TxHash: "register"}, nil
+}
+
+func (f *fakeBackend) DeregisterSupernodeTx(_ context.Context, _, _, _ string) (*types.ActionResult, error) {
+	f.calls = append(f.calls, "deregister")
+	return &types.ActionResult{TxHash: "deregister"}, nil
+}
+
+func (f *fakeBackend) StartSupernodeTx(_ context.Context, _, _, _ string) (*types.ActionResult, error) {
+	f.calls = append(f.calls, "start")
+	return &types.ActionResult{TxHash: "start"}, nil
+}
+
+func (f *fakeBackend) StopSupernodeTx(_ context.Context, _, _, _, _ string) (*types.ActionResult, error) {
+	f.calls = append(f.calls, "stop")
+	return &types.ActionResult{TxHash: "stop"}, nil
+}
+
+func (f *fakeBackend) UpdateSupernodeTx(_ context.Context, _, _, ip, note, account, p2p, _ string) (*types.ActionResult, error) {
+	f.calls = append(f.calls, "update")
+	f.update = []string{ip, note, account, p2p}
+	return &types.ActionResult{TxHash: "update"}, nil
+}
+
+func testAddrs(t *testing.T) (creator, valoper, snAccount string) {
+	t.Helper()
+	bz := []byte("validator-operator-01")
+	var err error
+	creator, err = bech32.ConvertAndEncode("lumera", bz)
+	require.NoError(t, err)
+	valoper, err = bech32.ConvertAndEncode("lumeravaloper", bz)
+	require.NoError(t, err)
+	snAccount, err = bech32.ConvertAndEncode("lumera", []byte("supernode-account-01"))
+	require.NoError(t, err)
+	return creator, valoper, snAccount
+}
+
+func newTestOperator(t *testing.T, backend Backend, creator string, reachable bool) *Operator {
+	t.Helper()
+	op, err := NewOperator(backend, creator, OperatorConfig{})
+	require.NoError(t, err)
+	op.dial = func(context.Context, string, string) (net.Conn, error) {
+		if !reachable {
+			return nil, errors.New("connection refused")
+		}
+		c1, c2 := net.Pipe()
+		_ = c2.Close()
+		return c1, nil
+	}
+	return op
+}
+
+func TestOperatorReconcileRegister(t *testing.T) {
+	creator, valoper, snAccount := testAddrs(t)
+	backend := &fakeBackend{validator: &stakingtypes.Validator{Status: stakingtypes.Bonded}}
+	op := newTestOperator(t, backend, creator, true)
+
+	res, err := op.Reconcile(context.Background(), DesiredState{
+		ValidatorAddress: valoper,
+		IPAddress:        "192.168.1.10:4444",
+		SupernodeAccount: snAccount,
+		Note:             "v2.4.72",
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"register", "update"}, backend.calls)
+	require.Equal(t, []string{"", "v2.4.72", "", ""}, backend.update)
+	require.Len(t, res.Results, 2)
+}
+
+func TestOperatorReconcileRegisterGRPCNotFound(t *testing.T) {
+	creator, valoper, snAccount := testAddrs(t)
+	backend := &fakeBackend{
+		notFound:  status.Error(codes.NotFound, "supernode not found"),
+		validator: &stakingtypes.Validator{Status: stakingtypes.Bonded},
+	}
+	op := newTestOperator(t, backend, creator, true)
+
+	_, err := op.Reconcile(context.Background(), DesiredState{ValidatorAddress: valoper, IPAddress: "192.168.1.10", SupernodeAccount: snAccount})
+	require.NoError(t, err)
+	require.Equal(t, []string{"register"}, backend.calls)
+	require.Equal(t, []string{"192.168.1.10", snAccount, ""}, backend.register)
+
+	backend.notFound = status.Error(codes.Unavailable, "down")
+	_, err = op.Plan(context.Background(), DesiredState{ValidatorAddress: valoper, IPAddress: "192.168.1.10", SupernodeAccount: snAccount})
+	require.Error(t, err)
+}
+
+func TestOperatorReconcileDisabled(t *testing.T) {
+	creator, valoper, snAccount := testAddrs(t)
+	current := &types.SuperNode{
+		ValidatorAddress: valoper,
+		SupernodeAccount: snAccount,
+		IPAddress:        "192.168.1.10",
+		P2PPort:          "4445",
+		State:            supernodetypes.SuperNodeStateDisabled.String(),
+	}
+	backend := &fakeBackend{sn: current, validator: &stakingtypes.Validator{Status: stakingtypes.Bonded}}
+	op := newTestOperator(t, backend, creator, true)
+
+	// The register message carries the on-chain fields; the new IP follows as an update.
+	_, err := op.Reconcile(context.Background(), DesiredState{ValidatorAddress: valoper, IPAddress: "192.168.1.11"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"register", "update"}, backend.calls)
+	require.Equal(t, []string{"192.168.1.11", snAccount, "4445"}, backend.register)
+	require.Equal(t, []string{"192.168.1.11", "", "", ""}, backend.update)
+
+	backend.calls = nil
+	_, err = op.Reconcile(context.Background(), DesiredState{ValidatorAddress: valoper})
+	require.NoError(t, err)
+	require.Equal(t, []string{"register"}, backend.calls)
+	require.Equal(t, []string{"192.168.1.10", snAccount, "4445"}, backend.register)
+}
+
+func TestOperatorReconcileExisting(t *testing.T) {
+	creator, valoper, snAccount := testAddrs(t)
+	current := &types.SuperNode{
+		ValidatorAddress: valoper,
+		SupernodeAccount: snAccount,
+		IPAddress:        "192.168.1.10",
+		P2PPort:          "4445",
+		State:            supernodetypes.SuperNodeStateStopped.String(),
+	}
+	backend := &fakeBackend{sn: current, validator: &stakingtypes.Validator{Status: stakingtypes.Bonded}}
+	op := newTestOperator(t, backend, creator, true)
+
+	// Matching config and state: nothing to do.
+	plan, err := op.Plan(context.Background(), DesiredState{ValidatorAddress: valoper, IPAddress: "192.168.1.10", State: LifecycleStopped})
+	require.NoError(t, err)
+	require.True(t, plan.Empty())
+
+	// New IP and desired active: update then start.
+	_, err = op.Reconcile(context.Background(), DesiredState{ValidatorAddress: valoper, IPAddress: "192.168.1.11"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"update", "start"}, backend.calls)
+	require.Equal(t, []string{"192.168.1.11", "", "", ""}, backend.update)
+
+	backend.calls = nil
+	current.State = supernodetypes.SuperNodeStateActive.String()
+	_, err = op.Reconcile(context.Background(), DesiredState{ValidatorAddress: valoper, State: LifecycleDeregistered})
+	require.NoError(t, err)
+	require.Equal(t, []string{"deregister"}, backend.calls)
+}
+
+func TestOperatorPreflight(t *testing.T) {
+	creator, valoper, snAccount := testAddrs(t)
+	ctx := context.Background()
+	desired := DesiredState{ValidatorAddress: valoper, IPAddress: "192.168.1.10", SupernodeAccount: snAccount}
+
+	unbonded := &fakeBackend{validator: &stakingtypes.Validator{Status: stakingtypes.Unbonded}}
+	require.ErrorContains(t, newTestOperator(t, unbonded, creator, true).Preflight(ctx, desired), "not bonded")
+
+	jailed := &fakeBackend{validator: &stakingtypes.Validator{Status: stakingtypes.Bonded, Jailed: true}}
+	require.ErrorContains(t, newTestOperator(t, jailed, creator, true).Preflight(ctx, desired), "jailed")
+
+	missing := &fakeBackend{}
+	require.Error(t, newTestOperator(t, missing, creator, true).Preflight(ctx, desired))
+
+	bonded := &fakeBackend{validator: &stakingtypes.Validator{Status: stakingtypes.Bonded}}
+	require.ErrorContains(t, newTestOperator(t, bonded, creator, false).Preflight(ctx, desired), "not reachable")
+
+	op := newTestOperator(t, bonded, creator, true)
+	require.NoError(t, op.Preflight(ctx, desired))
+
+	bad := desired
+	bad.IPAddress = "not a host:99999"
+	require.Error(t, op.Preflight(ctx, bad))
+	bad = desired
+	bad.P2PPort = "0"
+	require.Error(t, op.Preflight(ctx, bad))
+	bad = desired
+	bad.ValidatorAddress = snAccount
+	require.Error(t, op.Preflight(ctx, bad))
+
+	_, _, other := testAddrs(t)
+	otherOp := newTestOperator(t, bonded, other, true)
+	require.ErrorContains(t, otherOp.Preflight(ctx, desired), "not the operator account")
+}
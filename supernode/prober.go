@@ -0,0 +1,347 @@
+// Package supernode provides operator and uploader tooling built on top of the
+// chain supernode registry: health probing, lifecycle management and local
+// top-supernode selection.
+package supernode
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	supernodetypes "github.com/LumeraProtocol/lumera/x/supernode/v1/types"
+	pb "github.com/LumeraProtocol/supernode/v2/gen/supernode"
+	"go.uber.org/zap"
+
+	"github.com/LumeraProtocol/sdk-go/types"
+)
+
+// StatusClient queries a supernode's status endpoint by supernode account.
+// *cascade.Client satisfies it.
+type StatusClient interface {
+	GetSupernodeStatus(ctx context.Context, supernodeAddress string) (*pb.StatusResponse, error)
+}
+
+// Registry lists supernodes from the chain. *blockchain.SuperNodeClient
+// satisfies it.
+type Registry interface {
+	ListSuperNodes(ctx context.Context, limit, offset uint64) ([]*types.SuperNode, error)
+	GetTopSuperNodesForBlockWithOptions(ctx context.Context, blockHeight int32, limit int32, state string) ([]*types.SuperNode, error)
+}
+
+// ProberConfig configures a Prober.
+type ProberConfig struct {
+	// Timeout bounds each status call (default 10s).
+	Timeout time.Duration
+	// Concurrency limits parallel status calls (default 16).
+	Concurrency int
+	// PageSize used when listing all supernodes (default 100).
+	PageSize uint64
+	// Logger is optional; when set, probe failures are logged at debug level.
+	Logger *zap.Logger
+}
+
+// Prober checks supernode health through their status endpoints.
+type Prober struct {
+	registry Registry
+	status   StatusClient
+	cfg      ProberConfig
+}
+
+// NodeHealth is the probe outcome for one supernode.
+type NodeHealth struct {
+	ValidatorAddress string
+	SupernodeAccount string
+	// ChainIPAddress and ChainState are the latest values registered on chain.
+	ChainIPAddress string
+	ChainState     string
+
+	Reachable bool
+	Error     string
+	Latency   time.Duration
+
+	// Reported by the supernode; zero when unreachable.
+	Version          string
+	ReportedIP       string
+	Rank             int32
+	UptimeSeconds    uint64
+	CPUCores         int32
+	CPUUsagePercent  float64
+	MemAvailableGB   float64
+	MemUsagePercent  float64
+	StorageFreeBytes uint64
+	RunningTasks     int
+	PeersCount       int32
+
+	// Discrepancies lists differences between the chain record and what the
+	// node reports; empty when they agree.
+	Discrepancies []string
+}
+
+// Healthy reports whether the node is reachable and consistent with the chain.
+func (h NodeHealth) Healthy() bool {
+	return h.Reachable && len(h.Discrepancies) == 0
+}
+
+// Report is a ranked set of probe results. Healthy nodes come first, then
+// reachable nodes with discrepancies, then unreachable ones; within each group
+// nodes are ordered by latency.
+type Report struct {
+	CheckedAt time.Time
+	Nodes     []NodeHealth
+}
+
+// Reachable returns the reachable nodes in rank order.
+func (r *Report) Reachable() []NodeHealth {
+	out := make([]NodeHealth, 0, len(r.Nodes))
+	for _, n := range r.Nodes {
+		if n.Reachable {
+			out = append(out, n)
+		}
+	}
+	return out
+}
+
+// Healthy returns the healthy nodes in rank order.
+func (r *Report) Healthy() []NodeHealth {
+	out := make([]NodeHealth, 0, len(r.Nodes))
+	for _, n := range r.Nodes {
+		if n.Healthy() {
+			out = append(out, n)
+		}
+	}
+	return out
+}
+
+// NewProber creates a prober. registry may be nil when only Probe is used.
+func NewProber(registry Registry, status StatusClient, cfg ProberConfig) (*Prober, error) {
+	if status == nil {
+		return nil, fmt.Errorf("status client is required")
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = 16
+	}
+	if cfg.PageSize == 0 {
+		cfg.PageSize = 100
+	}
+	return &Prober{registry: registry, status: status, cfg: cfg}, nil
+}
+
+// ProbeAll probes every registered supernode.
+func (p *Prober) ProbeAll(ctx context.Context) (*Report, error) {
+	if p.registry == nil {
+		return nil, fmt.Errorf("registry is required")
+	}
+	var nodes []*types.SuperNode
+	for offset := uint64(0); ; offset += p.cfg.PageSize {
+		page, err := p.registry.ListSuperNodes(ctx, p.cfg.PageSize, offset)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, page...)
+		if uint64(len(page)) < p.cfg.PageSize {
+			break
+		}
+	}
+	return p.Probe(ctx, nodes), nil
+}
+
+// ProbeTop probes the top supernodes for a block height (limit 0 uses the
+// chain default).
+func (p *Prober) ProbeTop(ctx context.Context, height int32, limit int32) (*Report, error) {
+	if p.registry == nil {
+		return nil, fmt.Errorf("registry is required")
+	}
+	nodes, err := p.registry.GetTopSuperNodesForBlockWithOptions(ctx, height, limit, "")
+	if err != nil {
+		return nil, err
+	}
+	return p.Probe(ctx, nodes), nil
+}
+
+// Probe concurrently queries the status endpoint of each node and returns a
+// ranked report.
+func (p *Prober) Probe(ctx context.Context, nodes []*types.SuperNode) *Report {
+	results := make([]NodeHealth, 0, len(nodes))
+	var (
+		mu  sync.Mutex
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, p.cfg.Concurrency)
+	)
+	for _, sn := range nodes {
+		if sn == nil {
+			continue
+		}
+		wg.Add(1)
+		go func(sn *types.SuperNode) {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				h := baseHealth(sn)
+				h.Error = ctx.Err().Error()
+				mu.Lock()
+				results = append(results, h)
+				mu.Unlock()
+				return
+			}
+			h := p.probeOne(ctx, sn)
+			mu.Lock()
+			results = append(results, h)
+			mu.Unlock()
+		}(sn)
+	}
+	wg.Wait()
+
+	rankNodes(results)
+	return &Report{CheckedAt: time.Now(), Nodes: results}
+}
+
+// Run probes on every interval tick until ctx is cancelled, passing each
+// outcome to handle. Pass p.ProbeAll or a closure over p.ProbeTop as probe.
+func (p *Prober) Run(ctx context.Context, interval time.Duration, probe func(context.Context) (*Report, error), handle func(*Report, error)) error {
+	if interval <= 0 {
+		return fmt.Errorf("interval must be positive")
+	}
+	if probe == nil || handle == nil {
+		return fmt.Errorf("probe and handle are required")
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		handle(probe(ctx))
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *Prober) probeOne(ctx context.Context, sn *types.SuperNode) NodeHealth {
+	h := baseHealth(sn)
+	if sn.SupernodeAccount == "" {
+		h.Error = "supernode account not registered"
+		h.Discrepancies = chainDiscrepancies(h, nil)
+		return h
+	}
+
+	callCtx, cancel := context.WithTimeout(ctx, p.cfg.Timeout)
+	defer cancel()
+	start := time.Now()
+	st, err := p.status.GetSupernodeStatus(callCtx, sn.SupernodeAccount)
+	h.Latency = time.Since(start)
+	if err != nil || st == nil {
+		if err == nil {
+			err = fmt.Errorf("empty status response")
+		}
+		h.Error = err.Error()
+		if p.cfg.Logger != nil {
+			p.cfg.Logger.Debug("supernode probe failed", zap.String("validator", sn.ValidatorAddress), zap.Error(err))
+		}
+		h.Discrepancies = chainDiscrepancies(h, nil)
+		return h
+	}
+
+	h.Reachable = true
+	h.Version = st.Version
+	h.ReportedIP = st.IpAddress
+	h.Rank = st.Rank
+	h.UptimeSeconds = st.UptimeSeconds
+	if r := st.Resources; r != nil {
+		if r.Cpu != nil {
+			h.CPUCores = r.Cpu.Cores
+			h.CPUUsagePercent = r.Cpu.UsagePercent
+		}
+		if r.Memory != nil {
+			h.MemAvailableGB = r.Memory.AvailableGb
+			h.MemUsagePercent = r.Memory.UsagePercent
+		}
+		for _, v := range r.StorageVolumes {
+			if v != nil {
+				h.StorageFreeBytes += v.AvailableBytes
+			}
+		}
+	}
+	for _, t := range st.RunningTasks {
+		if t != nil {
+			h.RunningTasks += int(t.TaskCount)
+		}
+	}
+	if st.Network != nil {
+		h.PeersCount = st.Network.PeersCount
+	}
+	h.Discrepancies = chainDiscrepancies(h, st)
+	return h
+}
+
+func baseHealth(sn *types.SuperNode) NodeHealth {
+	return NodeHealth{
+		ValidatorAddress: sn.ValidatorAddress,
+		SupernodeAccount: sn.SupernodeAccount,
+		ChainIPAddress:   sn.IPAddress,
+		ChainState:       sn.State,
+	}
+}
+
+// chainDiscrepancies compares the chain record with the probe outcome.
+func chainDiscrepancies(h NodeHealth, st *pb.StatusResponse) []string {
+	active := h.ChainState == supernodetypes.SuperNodeStateActive.String()
+	var out []string
+	switch {
+	case active && st == nil:
+		out = append(out, "chain state is active but node is unreachable")
+	case !active && st != nil:
+		out = append(out, fmt.Sprintf("node is serving but chain state is %s", displayState(h.ChainState)))
+	}
+	if st != nil && st.IpAddress != "" && h.ChainIPAddress != "" && hostOf(st.IpAddress) != hostOf(h.ChainIPAddress) {
+		out = append(out, fmt.Sprintf("reported IP %s differs from chain IP %s", st.IpAddress, h.ChainIPAddress))
+	}
+	return out
+}
+
+func displayState(s string) string {
+	if s == "" {
+		return "unset"
+	}
+	return s
+}
+
+// hostOf strips an optional port so "1.2.3.4:4444" matches "1.2.3.4".
+func hostOf(addr string) string {
+	addr = strings.TrimSpace(addr)
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+	return strings.Trim(addr, "[]")
+}
+
+func rankNodes(nodes []NodeHealth) {
+	group := func(h NodeHealth) int {
+		switch {
+		case h.Healthy():
+			return 0
+		case h.Reachable:
+			return 1
+		default:
+			return 2
+		}
+	}
+	sort.SliceStable(nodes, func(i, j int) bool {
+		gi, gj := group(nodes[i]), group(nodes[j])
+		if gi != gj {
+			return gi < gj
+		}
+		if nodes[i].Reachable && nodes[i].Latency != nodes[j].Latency {
+			return nodes[i].Latency < nodes[j].Latency
+		}
+		return nodes[i].ValidatorAddress < nodes[j].ValidatorAddress
+	})
+}
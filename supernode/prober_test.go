@@ -0,0 +1,115 @@
+package supernode
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	supernodetypes "github.com/LumeraProtocol/lumera/x/supernode/v1/types"
+	pb "github.com/LumeraProtocol/supernode/v2/gen/supernode"
+	"github.com/stretchr/testify/require"
+
+	"github.com/LumeraProtocol/sdk-go/types"
+)
+
+type fakeStatus struct {
+	delay    map[string]time.Duration
+	statuses map[string]*pb.StatusResponse
+}
+
+func (f *fakeStatus) GetSupernodeStatus(ctx context.Context, addr string) (*pb.StatusResponse, error) {
+	select {
+	case <-time.After(f.delay[addr]):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	st, ok := f.statuses[addr]
+	if !ok {
+		return nil, errors.New("connection refused")
+	}
+	return st, nil
+}
+
+type fakeRegistry struct{ nodes []*types.SuperNode }
+
+func (f *fakeRegistry) ListSuperNodes(_ context.Context, limit, offset uint64) ([]*types.SuperNode, error) {
+	if offset >= uint64(len(f.nodes)) {
+		return nil, nil
+	}
+	end := offset + limit
+	if end > uint64(len(f.nodes)) {
+		end = uint64(len(f.nodes))
+	}
+	return f.nodes[offset:end], nil
+}
+
+func (f *fakeRegistry) GetTopSuperNodesForBlockWithOptions(_ context.Context, _ int32, limit int32, _ string) ([]*types.SuperNode, error) {
+	if limit > 0 && int(limit) < len(f.nodes) {
+		return f.nodes[:limit], nil
+	}
+	return f.nodes, nil
+}
+
+func TestProberRanksNodes(t *testing.T) {
+	active := supernodetypes.SuperNodeStateActive.String()
+	nodes := []*types.SuperNode{
+		{ValidatorAddress: "val-slow", SupernodeAccount: "sn-slow", IPAddress: "10.0.0.1", State: active},
+		{ValidatorAddress: "val-fast", SupernodeAccount: "sn-fast", IPAddress: "10.0.0.2", State: active},
+		{ValidatorAddress: "val-down", SupernodeAccount: "sn-down", IPAddress: "10.0.0.3", State: active},
+		{ValidatorAddress: "val-hung", SupernodeAccount: "sn-hung", IPAddress: "10.0.0.4", State: active},
+		{ValidatorAddress: "val-stopped", SupernodeAccount: "sn-stopped", IPAddress: "10.0.0.5", State: supernodetypes.SuperNodeStateStopped.String()},
+	}
+	status := &fakeStatus{
+		delay: map[string]time.Duration{"sn-slow": 30 * time.Millisecond, "sn-hung": time.Second},
+		statuses: map[string]*pb.StatusResponse{
+			"sn-slow": {Version: "v2.4.72", IpAddress: "10.0.0.1:4444"},
+			"sn-fast": {
+				Version:   "v2.4.72",
+				IpAddress: "10.0.0.2:4444",
+				Resources: &pb.StatusResponse_Resources{
+					Cpu:            &pb.StatusResponse_Resources_CPU{Cores: 8, UsagePercent: 12},
+					StorageVolumes: []*pb.StatusResponse_Resources_Storage{{AvailableBytes: 100}, {AvailableBytes: 50}},
+				},
+				RunningTasks: []*pb.StatusResponse_ServiceTasks{{TaskCount: 2}},
+			},
+			"sn-hung":    {},
+			"sn-stopped": {IpAddress: "10.0.0.9"},
+		},
+	}
+
+	p, err := NewProber(&fakeRegistry{nodes: nodes}, status, ProberConfig{Timeout: 200 * time.Millisecond, PageSize: 2})
+	require.NoError(t, err)
+	report, err := p.ProbeAll(context.Background())
+	require.NoError(t, err)
+	require.Len(t, report.Nodes, 5)
+
+	order := make([]string, len(report.Nodes))
+	for i, n := range report.Nodes {
+		order[i] = n.ValidatorAddress
+	}
+	require.Equal(t, []string{"val-fast", "val-slow", "val-stopped", "val-down", "val-hung"}, order)
+
+	fast := report.Nodes[0]
+	require.True(t, fast.Healthy())
+	require.Equal(t, "v2.4.72", fast.Version)
+	require.Equal(t, int32(8), fast.CPUCores)
+	require.Equal(t, uint64(150), fast.StorageFreeBytes)
+	require.Equal(t, 2, fast.RunningTasks)
+
+	stopped := report.Nodes[2]
+	require.True(t, stopped.Reachable)
+	require.Len(t, stopped.Discrepancies, 2)
+
+	hung := report.Nodes[4]
+	require.False(t, hung.Reachable)
+	require.NotEmpty(t, hung.Error)
+	require.Len(t, hung.Discrepancies, 1)
+
+	require.Len(t, report.Healthy(), 2)
+	require.Len(t, report.Reachable(), 3)
+
+	top, err := p.ProbeTop(context.Background(), 10, 2)
+	require.NoError(t, err)
+	require.Len(t, top.Nodes, 2)
+}
@@ -0,0 +1,247 @@
+package supernode
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	supernodetypes "github.com/LumeraProtocol/lumera/x/supernode/v1/types"
+	"lukechampine.com/blake3"
+
+	"github.com/LumeraProtocol/sdk-go/constants"
+	"github.com/LumeraProtocol/sdk-go/types"
+)
+
+// DefaultTopLimit mirrors the chain default for GetTopSuperNodesForBlock.
+const DefaultTopLimit = 25
+
+// BlockHash returns the selection seed the chain uses for a block height.
+func BlockHash(height int64) []byte {
+	h := blake3.Sum256([]byte(strconv.FormatInt(height, 10)))
+	return h[:]
+}
+
+// SelectTop reproduces the chain's GetTopSuperNodesForBlock locally from a
+// supernode snapshot: nodes are filtered by their state at height (postponed
+// nodes only when requested explicitly) and ranked by the XOR distance between
+// the block hash and the BLAKE3 hash of the validator address. limit <= 0 uses
+// DefaultTopLimit; state accepts full or short names ("ACTIVE") and "" for any.
+func SelectTop(nodes []*types.SuperNode, height int64, limit int, state string) ([]*types.SuperNode, error) {
+	if height <= 0 {
+		return nil, fmt.Errorf("invalid block height %d", height)
+	}
+	if limit <= 0 {
+		limit = DefaultTopLimit
+	}
+	filter := normalizeState(state)
+	seed := BlockHash(height)
+
+	type ranked struct {
+		sn       *types.SuperNode
+		distance *big.Int
+	}
+	candidates := make([]ranked, 0, len(nodes))
+	for _, sn := range nodes {
+		if sn == nil {
+			continue
+		}
+		at := sn.StateAt(height)
+		if at == "" || at == supernodetypes.SuperNodeStateUnspecified.String() {
+			continue
+		}
+		if filter == "" && at == supernodetypes.SuperNodeStatePostponed.String() {
+			continue
+		}
+		if filter != "" && at != filter {
+			continue
+		}
+		addr, err := decodeBech32(sn.ValidatorAddress, constants.LumeraValidatorHRP)
+		if err != nil {
+			continue
+		}
+		h := blake3.Sum256(addr)
+		candidates = append(candidates, ranked{sn: sn, distance: xorDistance(seed, h[:])})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if c := candidates[i].distance.Cmp(candidates[j].distance); c != 0 {
+			return c < 0
+		}
+		return candidates[i].sn.ValidatorAddress < candidates[j].sn.ValidatorAddress
+	})
+	if len(candidates) < limit {
+		limit = len(candidates)
+	}
+	out := make([]*types.SuperNode, limit)
+	for i := range out {
+		out[i] = candidates[i].sn
+	}
+	return out, nil
+}
+
+func normalizeState(state string) string {
+	s := strings.ToUpper(strings.TrimSpace(state))
+	switch s {
+	case "", "UNSPECIFIED", supernodetypes.SuperNodeStateUnspecified.String():
+		return ""
+	}
+	if _, ok := supernodetypes.SuperNodeState_value[s]; ok {
+		return s
+	}
+	if _, ok := supernodetypes.SuperNodeState_value["SUPERNODE_STATE_"+s]; ok {
+		return "SUPERNODE_STATE_" + s
+	}
+	// The chain treats unknown filters as unspecified.
+	return ""
+}
+
+func xorDistance(a, b []byte) *big.Int {
+	n := len(a)
+	if len(b) > n {
+		n = len(b)
+	}
+	out := make([]byte, n)
+	for i := 0; i < n; i++ {
+		var av, bv byte
+		if i < len(a) {
+			av = a[i]
+		}
+		if i < len(b) {
+			bv = b[i]
+		}
+		out[i] = av ^ bv
+	}
+	return new(big.Int).SetBytes(out)
+}
+
+// SelectorConfig configures a Selector.
+type SelectorConfig struct {
+	// PageSize used when loading the supernode snapshot (default 100).
+	PageSize uint64
+	// CacheSize bounds the number of cached selections (default 256).
+	CacheSize int
+}
+
+type selectionKey struct {
+	height int64
+	limit  int
+	state  string
+}
+
+// Selector answers top-supernode queries locally from a snapshot of the
+// registry and caches the result per height.
+type Selector struct {
+	registry Registry
+	cfg      SelectorConfig
+
+	mu       sync.Mutex
+	snapshot []*types.SuperNode
+	loaded   bool
+	cache    map[selectionKey][]*types.SuperNode
+	order    []selectionKey
+}
+
+// NewSelector creates a selector. registry may be nil when the snapshot is
+// provided through SetSnapshot.
+func NewSelector(registry Registry, cfg SelectorConfig) *Selector {
+	if cfg.PageSize == 0 {
+		cfg.PageSize = 100
+	}
+	if cfg.CacheSize <= 0 {
+		cfg.CacheSize = 256
+	}
+	return &Selector{registry: registry, cfg: cfg, cache: make(map[selectionKey][]*types.SuperNode)}
+}
+
+// SetSnapshot replaces the supernode snapshot and clears the cache.
+func (s *Selector) SetSnapshot(nodes []*types.SuperNode) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.snapshot = append([]*types.SuperNode(nil), nodes...)
+	s.loaded = true
+	s.cache = make(map[selectionKey][]*types.SuperNode)
+	s.order = nil
+}
+
+// Refresh reloads the snapshot from the registry.
+func (s *Selector) Refresh(ctx context.Context) error {
+	if s.registry == nil {
+		return fmt.Errorf("registry is required")
+	}
+	var nodes []*types.SuperNode
+	for offset := uint64(0); ; offset += s.cfg.PageSize {
+		page, err := s.registry.ListSuperNodes(ctx, s.cfg.PageSize, offset)
+		if err != nil {
+			return err
+		}
+		nodes = append(nodes, page...)
+		if uint64(len(page)) < s.cfg.PageSize {
+			break
+		}
+	}
+	s.SetSnapshot(nodes)
+	return nil
+}
+
+// Top returns the top supernodes for height, loading the snapshot on first use.
+func (s *Selector) Top(ctx context.Context, height int64, limit int, state string) ([]*types.SuperNode, error) {
+	s.mu.Lock()
+	loaded := s.loaded
+	s.mu.Unlock()
+	if !loaded {
+		if err := s.Refresh(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	if limit <= 0 {
+		limit = DefaultTopLimit
+	}
+	key := selectionKey{height: height, limit: limit, state: normalizeState(state)}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if cached, ok := s.cache[key]; ok {
+		return append([]*types.SuperNode(nil), cached...), nil
+	}
+	top, err := SelectTop(s.snapshot, height, limit, state)
+	if err != nil {
+		return nil, err
+	}
+	if len(s.order) >= s.cfg.CacheSize {
+		delete(s.cache, s.order[0])
+		s.order = s.order[1:]
+	}
+	s.cache[key] = top
+	s.order = append(s.order, key)
+	return append([]*types.SuperNode(nil), top...), nil
+}
+
+// Verify compares the local selection with the chain query for height and
+// returns an error describing the first mismatch.
+func (s *Selector) Verify(ctx context.Context, height int64, limit int, state string) error {
+	if s.registry == nil {
+		return fmt.Errorf("registry is required")
+	}
+	local, err := s.Top(ctx, height, limit, state)
+	if err != nil {
+		return err
+	}
+	remote, err := s.registry.GetTopSuperNodesForBlockWithOptions(ctx, int32(height), int32(limit), state)
+	if err != nil {
+		return err
+	}
+	if len(local) != len(remote) {
+		return fmt.Errorf("height %d: local selection has %d supernodes, chain has %d", height, len(local), len(remote))
+	}
+	for i := range local {
+		if local[i].ValidatorAddress != remote[i].ValidatorAddress {
+			return fmt.Errorf("height %d: rank %d is %s locally, %s on chain", height, i, local[i].ValidatorAddress, remote[i].ValidatorAddress)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package supernode
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	snkeeper "github.com/LumeraProtocol/lumera/x/supernode/v1/keeper"
+	supernodetypes "github.com/LumeraProtocol/lumera/x/supernode/v1/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/stretchr/testify/require"
+
+	"github.com/LumeraProtocol/sdk-go/types"
+)
+
+// chainTop runs the chain keeper's own filtering and ranking over the same
+// supernodes, standing in for the GetTopSuperNodesForBlock query.
+func chainTop(t *testing.T, pbs []supernodetypes.SuperNode, height int64, limit int, postponed bool) []string {
+	t.Helper()
+	var valid []supernodetypes.SuperNode
+	for _, sn := range pbs {
+		st, ok := snkeeper.DetermineStateAtBlock(sn.States, height)
+		if !ok || st == supernodetypes.SuperNodeStateUnspecified {
+			continue
+		}
+		if postponed != (st == supernodetypes.SuperNodeStatePostponed) {
+			continue
+		}
+		valid = append(valid, sn)
+	}
+	var k snkeeper.Keeper
+	hash, err := k.GetBlockHashForHeight(sdk.Context{}, height)
+	require.NoError(t, err)
+	var out []string
+	for _, sn := range k.RankSuperNodesByDistance(hash, valid, limit) {
+		out = append(out, sn.ValidatorAddress)
+	}
+	return out
+}
+
+func testSnapshot(t *testing.T, n int) []supernodetypes.SuperNode {
+	t.Helper()
+	pbs := make([]supernodetypes.SuperNode, n)
+	for i := range pbs {
+		addr, err := bech32.ConvertAndEncode("lumeravaloper", []byte(fmt.Sprintf("validator-address-%03d", i)))
+		require.NoError(t, err)
+		states := []*supernodetypes.SuperNodeStateRecord{{State: supernodetypes.SuperNodeStateActive, Height: int64(i * 10)}}
+		// State history is append-only, so heights never decrease.
+		switch i % 5 {
+		case 1:
+			states = append(states, &supernodetypes.SuperNodeStateRecord{State: supernodetypes.SuperNodeStateStopped, Height: int64(i*10 + 5)})
+		case 2:
+			states = append(states, &supernodetypes.SuperNodeStateRecord{State: supernodetypes.SuperNodeStatePostponed, Height: int64(i*10 + 3)})
+		}
+		pbs[i] = supernodetypes.SuperNode{ValidatorAddress: addr, States: states}
+	}
+	return pbs
+}
+
+func addresses(sns []*types.SuperNode) []string {
+	var out []string
+	for _, sn := range sns {
+		out = append(out, sn.ValidatorAddress)
+	}
+	return out
+}
+
+func TestSelectTopMatchesChain(t *testing.T) {
+	cfg := sdk.GetConfig()
+	cfg.SetBech32PrefixForValidator("lumeravaloper", "lumeravaloperpub")
+
+	pbs := testSnapshot(t, 40)
+	nodes := make([]*types.SuperNode, len(pbs))
+	for i := range pbs {
+		nodes[i] = types.SuperNodeFromProto(&pbs[i])
+	}
+
+	for _, height := range []int64{1, 55, 121, 200, 1000} {
+		for _, limit := range []int{5, 25} {
+			got, err := SelectTop(nodes, height, limit, "")
+			require.NoError(t, err)
+			require.Equal(t, chainTop(t, pbs, height, limit, false), addresses(got), "height %d limit %d", height, limit)
+		}
+		got, err := SelectTop(nodes, height, 10, "POSTPONED")
+		require.NoError(t, err)
+		require.Equal(t, chainTop(t, pbs, height, 10, true), addresses(got), "height %d postponed", height)
+	}
+
+	_, err := SelectTop(nodes, 0, 10, "")
+	require.Error(t, err)
+}
+
+type countingRegistry struct {
+	fakeRegistry
+	lists int
+	top   []*types.SuperNode
+}
+
+func (c *countingRegistry) ListSuperNodes(ctx context.Context, limit, offset uint64) ([]*types.SuperNode, error) {
+	c.lists++
+	return c.fakeRegistry.ListSuperNodes(ctx, limit, offset)
+}
+
+func (c *countingRegistry) GetTopSuperNodesForBlockWithOptions(context.Context, int32, int32, string) ([]*types.SuperNode, error) {
+	return c.top, nil
+}
+
+func TestSelectorCachesAndVerifies(t *testing.T) {
+	pbs := testSnapshot(t, 12)
+	nodes := types.SuperNodesFromProto(func() []*supernodetypes.SuperNode {
+		out := make([]*supernodetypes.SuperNode, len(pbs))
+		for i := range pbs {
+			out[i] = &pbs[i]
+		}
+		return out
+	}())
+	reg := &countingRegistry{fakeRegistry: fakeRegistry{nodes: nodes}}
+	sel := NewSelector(reg, SelectorConfig{PageSize: 5, CacheSize: 2})
+
+	first, err := sel.Top(context.Background(), 100, 3, "")
+	require.NoError(t, err)
+	require.Len(t, first, 3)
+	require.Equal(t, 3, reg.lists)
+
+	again, err := sel.Top(context.Background(), 100, 3, "")
+	require.NoError(t, err)
+	require.Equal(t, addresses(first), addresses(again))
+	require.Equal(t, 3, reg.lists)
+
+	reg.top = first
+	require.NoError(t, sel.Verify(context.Background(), 100, 3, ""))
+	reg.top = []*types.SuperNode{first[1], first[0], first[2]}
+	require.Error(t, sel.Verify(context.Background(), 100, 3, ""))
+}
@@ -0,0 +1,82 @@
+package types
+
+import (
+	"time"
+
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// AccountType identifies the kind of an on-chain account.
+type AccountType string
+
+const (
+	AccountTypeBase              AccountType = "base"
+	AccountTypeModule            AccountType = "module"
+	AccountTypeContinuousVesting AccountType = "continuous_vesting"
+	AccountTypeDelayedVesting    AccountType = "delayed_vesting"
+	AccountTypePeriodicVesting   AccountType = "periodic_vesting"
+	AccountTypePermanentLocked   AccountType = "permanent_locked"
+	AccountTypeUnknown           AccountType = "unknown"
+)
+
+// IsVesting reports whether the account type locks part of its balance.
+func (t AccountType) IsVesting() bool {
+	switch t {
+	case AccountTypeContinuousVesting, AccountTypeDelayedVesting, AccountTypePeriodicVesting, AccountTypePermanentLocked:
+		return true
+	}
+	return false
+}
+
+// Account is a decoded auth module account.
+type Account struct {
+	Address       string
+	AccountNumber uint64
+	Sequence      uint64
+	// PubKey is nil until the account has signed a transaction.
+	PubKey  cryptotypes.PubKey
+	Type    AccountType
+	TypeURL string
+
+	// ModuleName and Permissions are set for module accounts.
+	ModuleName  string
+	Permissions []string
+
+	// Vesting is set for vesting accounts.
+	Vesting *VestingSchedule
+}
+
+// VestingSchedule describes the lockup of a vesting account.
+type VestingSchedule struct {
+	OriginalVesting  sdk.Coins
+	DelegatedFree    sdk.Coins
+	DelegatedVesting sdk.Coins
+	// StartTime is zero for delayed and permanently locked accounts.
+	StartTime time.Time
+	// EndTime is zero for permanently locked accounts.
+	EndTime time.Time
+	// Periods are set for periodic vesting accounts.
+	Periods []VestingPeriod
+}
+
+// VestingPeriod is one step of a periodic vesting schedule.
+type VestingPeriod struct {
+	Length time.Duration
+	Amount sdk.Coins
+}
+
+// AccountBalance splits an account's balance into spendable and locked parts
+// at a block time, following the bank module's rules.
+type AccountBalance struct {
+	Address   string
+	BlockTime time.Time
+	Total     sdk.Coins
+	Spendable sdk.Coins
+	// Locked is the still-vesting amount not covered by vesting delegations.
+	Locked sdk.Coins
+	// Vesting and Vested split the original vesting amount; both are empty
+	// for non-vesting accounts.
+	Vesting sdk.Coins
+	Vested  sdk.Coins
+}
@@ -1,8 +1,10 @@
 package types
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
-	"reflect"
+	"strings"
 	"time"
 
 	actiontypes "github.com/LumeraProtocol/lumera/x/action/v1/types"
@@ -12,17 +14,17 @@ import (
 
 // Action represents an action in the SDK
 type Action struct {
-	ID             string
-	Creator        string
-	AppPubkey      []byte
-	Type           ActionType
-	State          ActionState
-	Metadata       ActionMetadata
-	Price          string
-	ExpirationTime time.Time
-	BlockHeight    int64
-	FileSizeKbs    int64
-	SuperNodes     []string
+	ID             string         `json:"id"`
+	Creator        string         `json:"creator"`
+	AppPubkey      []byte         `json:"app_pubkey,omitempty"`
+	Type           ActionType     `json:"type"`
+	State          ActionState    `json:"state"`
+	Metadata       ActionMetadata `json:"metadata,omitempty"`
+	Price          sdk.Coin       `json:"price"`
+	ExpirationTime time.Time      `json:"expiration_time"`
+	BlockHeight    int64          `json:"block_height"`
+	FileSizeKbs    int64          `json:"file_size_kbs"`
+	SuperNodes     []string       `json:"super_nodes,omitempty"`
 }
 
 // ActionType represents the type of action
@@ -41,10 +43,14 @@ const (
 	ActionStateProcessing ActionState = "ACTION_STATE_PROCESSING"
 	ActionStateDone       ActionState = "ACTION_STATE_DONE"
 	ActionStateApproved   ActionState = "ACTION_STATE_APPROVED"
+	ActionStateRejected   ActionState = "ACTION_STATE_REJECTED"
 	ActionStateFailed     ActionState = "ACTION_STATE_FAILED"
 	ActionStateExpired    ActionState = "ACTION_STATE_EXPIRED"
 )
 
+// ErrInvalidMetadata is returned when action metadata cannot be decoded.
+var ErrInvalidMetadata = errors.New("invalid action metadata")
+
 // ActionMetadata is an interface for different action metadata types
 type ActionMetadata interface {
 	Type() ActionType
@@ -52,73 +58,174 @@ type ActionMetadata interface {
 
 // CascadeMetadata contains cascade-specific metadata
 type CascadeMetadata struct {
-	DataHash   string
-	FileName   string
-	RQIDsIC    uint64
-	RQIDsMax   uint64
-	RQIDsIDs   []string
-	Signatures string
-	Public     bool
+	DataHash   string   `json:"data_hash"`
+	FileName   string   `json:"file_name"`
+	RQIDsIC    uint64   `json:"rq_ids_ic"`
+	RQIDsMax   uint64   `json:"rq_ids_max"`
+	RQIDsIDs   []string `json:"rq_ids_ids,omitempty"`
+	Signatures string   `json:"signatures"`
+	Public     bool     `json:"public"`
 }
 
 func (m *CascadeMetadata) Type() ActionType {
 	return ActionTypeCascade
 }
 
+// MarshalJSON encodes the metadata with a "type" discriminator.
+func (m CascadeMetadata) MarshalJSON() ([]byte, error) {
+	type plain CascadeMetadata
+	return json.Marshal(struct {
+		Type ActionType `json:"type"`
+		plain
+	}{Type: ActionTypeCascade, plain: plain(m)})
+}
+
+// UnmarshalJSON decodes the metadata, rejecting a mismatched "type" discriminator.
+func (m *CascadeMetadata) UnmarshalJSON(data []byte) error {
+	type plain CascadeMetadata
+	var aux struct {
+		Type ActionType `json:"type"`
+		plain
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	if aux.Type != "" && aux.Type != ActionTypeCascade {
+		return fmt.Errorf("%w: expected type %s, got %s", ErrInvalidMetadata, ActionTypeCascade, aux.Type)
+	}
+	*m = CascadeMetadata(aux.plain)
+	return nil
+}
+
 // SenseMetadata contains sense-specific metadata
 type SenseMetadata struct {
-	DataHash             string
-	CollectionID         string
-	GroupID              string
-	DDAndFingerprintsIC  uint64
-	DDAndFingerprintsMax uint64
-	DDAndFingerprintsIDs []string
-	Signatures           string
+	DataHash             string   `json:"data_hash"`
+	CollectionID         string   `json:"collection_id,omitempty"`
+	GroupID              string   `json:"group_id,omitempty"`
+	DDAndFingerprintsIC  uint64   `json:"dd_and_fingerprints_ic"`
+	DDAndFingerprintsMax uint64   `json:"dd_and_fingerprints_max"`
+	DDAndFingerprintsIDs []string `json:"dd_and_fingerprints_ids,omitempty"`
+	Signatures           string   `json:"signatures"`
 }
 
 func (m *SenseMetadata) Type() ActionType {
 	return ActionTypeSense
 }
 
-// priceToString converts supported price representations to string.
-func priceToString(p interface{}) string {
-	if p == nil {
-		return ""
+// MarshalJSON encodes the metadata with a "type" discriminator.
+func (m SenseMetadata) MarshalJSON() ([]byte, error) {
+	type plain SenseMetadata
+	return json.Marshal(struct {
+		Type ActionType `json:"type"`
+		plain
+	}{Type: ActionTypeSense, plain: plain(m)})
+}
+
+// UnmarshalJSON decodes the metadata, rejecting a mismatched "type" discriminator.
+func (m *SenseMetadata) UnmarshalJSON(data []byte) error {
+	type plain SenseMetadata
+	var aux struct {
+		Type ActionType `json:"type"`
+		plain
 	}
-	if c, ok := p.(*sdk.Coin); ok {
-		if c == nil {
-			return ""
-		}
-		return c.String()
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
 	}
-	if c, ok := p.(sdk.Coin); ok {
-		return c.String()
+	if aux.Type != "" && aux.Type != ActionTypeSense {
+		return fmt.Errorf("%w: expected type %s, got %s", ErrInvalidMetadata, ActionTypeSense, aux.Type)
 	}
-	if s, ok := p.(string); ok {
-		return s
+	*m = SenseMetadata(aux.plain)
+	return nil
+}
+
+// UnmarshalActionMetadata decodes JSON metadata produced by CascadeMetadata or
+// SenseMetadata MarshalJSON, using the "type" discriminator to pick the type.
+// fallback is used when the discriminator is absent.
+func UnmarshalActionMetadata(data []byte, fallback ActionType) (ActionMetadata, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return nil, nil
+	}
+	var head struct {
+		Type ActionType `json:"type"`
+	}
+	if err := json.Unmarshal(data, &head); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidMetadata, err)
 	}
-	// As a fallback, use reflection to try a String() method
-	rv := reflect.ValueOf(p)
-	if rv.IsValid() {
-		m := rv.MethodByName("String")
-		if m.IsValid() && m.Type().NumIn() == 0 && m.Type().NumOut() == 1 && m.Type().Out(0).Kind() == reflect.String {
-			out := m.Call(nil)
-			return out[0].String()
+	t := head.Type
+	if t == "" {
+		t = fallback
+	}
+	switch t {
+	case ActionTypeCascade:
+		var m CascadeMetadata
+		if err := json.Unmarshal(data, &m); err != nil {
+			return nil, err
+		}
+		return &m, nil
+	case ActionTypeSense:
+		var m SenseMetadata
+		if err := json.Unmarshal(data, &m); err != nil {
+			return nil, err
 		}
+		return &m, nil
+	default:
+		return nil, fmt.Errorf("%w: unknown metadata type %q", ErrInvalidMetadata, t)
+	}
+}
+
+// UnmarshalJSON decodes an Action, resolving Metadata through its type discriminator.
+func (a *Action) UnmarshalJSON(data []byte) error {
+	type plain Action
+	var aux struct {
+		plain
+		Metadata json.RawMessage `json:"metadata,omitempty"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	meta, err := UnmarshalActionMetadata(aux.Metadata, aux.Type)
+	if err != nil {
+		return err
 	}
-	return fmt.Sprint(p)
+	*a = Action(aux.plain)
+	a.Metadata = meta
+	return nil
 }
 
-func decodeMetadata(metadataBytes []byte, at actiontypes.ActionType) ActionMetadata {
+func actionTypeFromProto(at actiontypes.ActionType) ActionType {
+	switch at {
+	case actiontypes.ActionTypeCascade:
+		return ActionTypeCascade
+	case actiontypes.ActionTypeSense:
+		return ActionTypeSense
+	default:
+		return ActionType(at.String())
+	}
+}
+
+// parsePrice converts the chain price string (e.g. "10000ulume") into a coin.
+// An empty price yields a zero coin.
+func parsePrice(price string) (sdk.Coin, error) {
+	if strings.TrimSpace(price) == "" {
+		return sdk.Coin{}, nil
+	}
+	coin, err := sdk.ParseCoinNormalized(price)
+	if err != nil {
+		return sdk.Coin{}, fmt.Errorf("parse price %q: %w", price, err)
+	}
+	return coin, nil
+}
+
+func decodeMetadata(metadataBytes []byte, at actiontypes.ActionType) (ActionMetadata, error) {
 	if len(metadataBytes) == 0 {
-		return nil
+		return nil, nil
 	}
 
 	switch at {
 	case actiontypes.ActionTypeCascade:
 		var pbMeta actiontypes.CascadeMetadata
 		if err := proto.Unmarshal(metadataBytes, &pbMeta); err != nil {
-			return nil
+			return nil, fmt.Errorf("%w: decode cascade metadata: %v", ErrInvalidMetadata, err)
 		}
 		return &CascadeMetadata{
 			DataHash:   pbMeta.DataHash,
@@ -128,11 +235,11 @@ func decodeMetadata(metadataBytes []byte, at actiontypes.ActionType) ActionMetad
 			RQIDsIDs:   append([]string(nil), pbMeta.RqIdsIds...),
 			Signatures: pbMeta.Signatures,
 			Public:     pbMeta.Public,
-		}
+		}, nil
 	case actiontypes.ActionTypeSense:
 		var pbMeta actiontypes.SenseMetadata
 		if err := proto.Unmarshal(metadataBytes, &pbMeta); err != nil {
-			return nil
+			return nil, fmt.Errorf("%w: decode sense metadata: %v", ErrInvalidMetadata, err)
 		}
 		return &SenseMetadata{
 			DataHash:             pbMeta.DataHash,
@@ -142,29 +249,56 @@ func decodeMetadata(metadataBytes []byte, at actiontypes.ActionType) ActionMetad
 			DDAndFingerprintsMax: pbMeta.DdAndFingerprintsMax,
 			DDAndFingerprintsIDs: append([]string(nil), pbMeta.DdAndFingerprintsIds...),
 			Signatures:           pbMeta.Signatures,
-		}
+		}, nil
 	default:
-		return nil
+		return nil, fmt.Errorf("%w: unsupported action type %s", ErrInvalidMetadata, at)
 	}
 }
 
-// ActionFromProto converts a proto action to SDK action
+// ActionFromProto converts a proto action to SDK action.
+// Metadata that fails to decode is left nil and an unparsable price yields a
+// zero coin; use ActionFromProtoStrict to surface those errors.
 func ActionFromProto(pb *actiontypes.Action) *Action {
 	if pb == nil {
 		return nil
 	}
+	action := actionFromProto(pb)
+	action.Metadata, _ = decodeMetadata(pb.Metadata, pb.ActionType)
+	action.Price, _ = parsePrice(pb.Price)
+	return action
+}
+
+// ActionFromProtoStrict converts a proto action to SDK action, returning an
+// error wrapping ErrInvalidMetadata when metadata is corrupt and an error when
+// the price cannot be parsed as a coin.
+func ActionFromProtoStrict(pb *actiontypes.Action) (*Action, error) {
+	if pb == nil {
+		return nil, fmt.Errorf("action is nil")
+	}
+	action := actionFromProto(pb)
+	meta, err := decodeMetadata(pb.Metadata, pb.ActionType)
+	if err != nil {
+		return nil, fmt.Errorf("action %s: %w", pb.ActionID, err)
+	}
+	price, err := parsePrice(pb.Price)
+	if err != nil {
+		return nil, fmt.Errorf("action %s: %w", pb.ActionID, err)
+	}
+	action.Metadata = meta
+	action.Price = price
+	return action, nil
+}
 
+func actionFromProto(pb *actiontypes.Action) *Action {
 	return &Action{
 		ID:             pb.ActionID,
 		Creator:        pb.Creator,
 		AppPubkey:      append([]byte(nil), pb.AppPubkey...),
-		Type:           ActionType(pb.ActionType.String()),
+		Type:           actionTypeFromProto(pb.ActionType),
 		State:          ActionState(pb.State.String()),
-		Metadata:       decodeMetadata(pb.Metadata, pb.ActionType),
-		Price:          priceToString(pb.Price),
 		ExpirationTime: time.Unix(pb.ExpirationTime, 0),
 		BlockHeight:    pb.BlockHeight,
 		FileSizeKbs:    pb.FileSizeKbs,
-		SuperNodes:     pb.SuperNodes,
+		SuperNodes:     append([]string(nil), pb.SuperNodes...),
 	}
 }
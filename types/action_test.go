@@ -0,0 +1,105 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	sdkmath "cosmossdk.io/math"
+	actiontypes "github.com/LumeraProtocol/lumera/x/action/v1/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	proto "github.com/cosmos/gogoproto/proto"
+	"github.com/stretchr/testify/require"
+)
+
+func cascadeProtoAction(t *testing.T) *actiontypes.Action {
+	t.Helper()
+	meta, err := proto.Marshal(&actiontypes.CascadeMetadata{
+		DataHash: "hash",
+		FileName: "file.txt",
+		RqIdsIc:  3,
+		RqIdsMax: 50,
+		Public:   true,
+	})
+	require.NoError(t, err)
+	return &actiontypes.Action{
+		Creator:        "lumera1creator",
+		ActionID:       "42",
+		ActionType:     actiontypes.ActionTypeCascade,
+		Metadata:       meta,
+		Price:          "10000ulume",
+		ExpirationTime: 1700000000,
+		State:          actiontypes.ActionStateDone,
+		BlockHeight:    7,
+		SuperNodes:     []string{"sn1"},
+		FileSizeKbs:    12,
+	}
+}
+
+func TestActionFromProtoStrict(t *testing.T) {
+	pb := cascadeProtoAction(t)
+
+	action, err := ActionFromProtoStrict(pb)
+	require.NoError(t, err)
+	require.Equal(t, ActionTypeCascade, action.Type)
+	require.Equal(t, ActionStateDone, action.State)
+	require.Equal(t, sdk.NewCoin("ulume", sdkmath.NewInt(10000)), action.Price)
+	meta, ok := action.Metadata.(*CascadeMetadata)
+	require.True(t, ok)
+	require.Equal(t, "hash", meta.DataHash)
+	require.True(t, meta.Public)
+
+	pb.Metadata = []byte{0xff, 0xff, 0xff}
+	_, err = ActionFromProtoStrict(pb)
+	require.True(t, errors.Is(err, ErrInvalidMetadata))
+
+	lenient := ActionFromProto(pb)
+	require.NotNil(t, lenient)
+	require.Nil(t, lenient.Metadata)
+
+	pb = cascadeProtoAction(t)
+	pb.Price = "not-a-coin"
+	_, err = ActionFromProtoStrict(pb)
+	require.Error(t, err)
+}
+
+func TestActionJSONRoundTrip(t *testing.T) {
+	action, err := ActionFromProtoStrict(cascadeProtoAction(t))
+	require.NoError(t, err)
+
+	bz, err := json.Marshal(action)
+	require.NoError(t, err)
+	require.Contains(t, string(bz), `"type":"CASCADE"`)
+
+	var decoded Action
+	require.NoError(t, json.Unmarshal(bz, &decoded))
+	require.Equal(t, action.ID, decoded.ID)
+	require.True(t, action.Price.IsEqual(decoded.Price))
+	require.True(t, action.ExpirationTime.Equal(decoded.ExpirationTime))
+	require.Equal(t, action.Metadata, decoded.Metadata)
+
+	sense := &Action{
+		ID:             "43",
+		Type:           ActionTypeSense,
+		Metadata:       &SenseMetadata{DataHash: "h", CollectionID: "c"},
+		ExpirationTime: time.Unix(0, 0).UTC(),
+	}
+	bz, err = json.Marshal(sense)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(bz, &decoded))
+	require.Equal(t, sense.Metadata, decoded.Metadata)
+}
+
+func TestUnmarshalActionMetadataRejectsMismatch(t *testing.T) {
+	_, err := UnmarshalActionMetadata([]byte(`{"type":"BOGUS"}`), "")
+	require.True(t, errors.Is(err, ErrInvalidMetadata))
+
+	var m CascadeMetadata
+	err = json.Unmarshal([]byte(`{"type":"SENSE","data_hash":"x"}`), &m)
+	require.True(t, errors.Is(err, ErrInvalidMetadata))
+
+	meta, err := UnmarshalActionMetadata([]byte(`{"data_hash":"x"}`), ActionTypeSense)
+	require.NoError(t, err)
+	require.Equal(t, &SenseMetadata{DataHash: "x"}, meta)
+}
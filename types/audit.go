@@ -0,0 +1,230 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+
+	audittypes "github.com/LumeraProtocol/lumera/x/audit/v1/types"
+	"github.com/cosmos/gogoproto/jsonpb"
+	gogoproto "github.com/cosmos/gogoproto/proto"
+)
+
+// AuditEvidence is an evidence record stored by the audit module.
+type AuditEvidence struct {
+	ID              uint64 `json:"id"`
+	SubjectAddress  string `json:"subject_address"`
+	ReporterAddress string `json:"reporter_address"`
+	ActionID        string `json:"action_id,omitempty"`
+	EvidenceType    string `json:"evidence_type"`
+	ReportedHeight  uint64 `json:"reported_height"`
+	// Metadata is the type-specific metadata rendered as JSON; nil when the
+	// type is unknown or the bytes cannot be decoded.
+	Metadata json.RawMessage `json:"metadata,omitempty"`
+	// RawMetadata holds the protobuf-encoded metadata as stored on chain.
+	RawMetadata []byte `json:"-"`
+}
+
+// EpochInfo describes the boundaries of an audit epoch.
+type EpochInfo struct {
+	ID          uint64 `json:"id"`
+	StartHeight int64  `json:"start_height"`
+	EndHeight   int64  `json:"end_height"`
+}
+
+// EpochAnchor is the persisted snapshot an audit epoch is evaluated against.
+type EpochAnchor struct {
+	EpochInfo
+	LengthBlocks            uint64   `json:"length_blocks"`
+	Seed                    []byte   `json:"seed"`
+	ActiveSupernodeAccounts []string `json:"active_supernode_accounts"`
+	TargetSupernodeAccounts []string `json:"target_supernode_accounts"`
+	ParamsCommitment        []byte   `json:"params_commitment"`
+	ActiveSetCommitment     []byte   `json:"active_set_commitment"`
+	TargetsSetCommitment    []byte   `json:"targets_set_commitment"`
+}
+
+// AssignedTargets lists the supernodes a prober must check in an epoch.
+type AssignedTargets struct {
+	EpochID           uint64   `json:"epoch_id"`
+	EpochStartHeight  int64    `json:"epoch_start_height"`
+	RequiredOpenPorts []uint32 `json:"required_open_ports"`
+	Targets           []string `json:"targets"`
+}
+
+// HostReport is a supernode's self-reported host health for an epoch.
+type HostReport struct {
+	CPUUsagePercent    float64  `json:"cpu_usage_percent"`
+	MemUsagePercent    float64  `json:"mem_usage_percent"`
+	DiskUsagePercent   float64  `json:"disk_usage_percent"`
+	InboundPortStates  []string `json:"inbound_port_states"`
+	FailedActionsCount uint32   `json:"failed_actions_count"`
+}
+
+// StorageChallengeObservation is a prober's view of a target's ports.
+type StorageChallengeObservation struct {
+	TargetSupernodeAccount string   `json:"target_supernode_account"`
+	PortStates             []string `json:"port_states"`
+}
+
+// EpochReport is the report a supernode submitted for an epoch.
+type EpochReport struct {
+	SupernodeAccount string                        `json:"supernode_account"`
+	EpochID          uint64                        `json:"epoch_id"`
+	ReportHeight     int64                         `json:"report_height"`
+	HostReport       HostReport                    `json:"host_report"`
+	Observations     []StorageChallengeObservation `json:"observations"`
+}
+
+// StorageChallengeReport is a report that includes observations about a
+// given supernode, as returned by the storage challenge query.
+type StorageChallengeReport struct {
+	ReporterSupernodeAccount string   `json:"reporter_supernode_account"`
+	EpochID                  uint64   `json:"epoch_id"`
+	ReportHeight             int64    `json:"report_height"`
+	PortStates               []string `json:"port_states"`
+}
+
+// HostReportEntry is a host report with the epoch it was submitted for.
+type HostReportEntry struct {
+	EpochID      uint64     `json:"epoch_id"`
+	ReportHeight int64      `json:"report_height"`
+	HostReport   HostReport `json:"host_report"`
+}
+
+// AuditEvidenceFromProto converts a proto evidence record to the SDK type.
+func AuditEvidenceFromProto(pb *audittypes.Evidence) *AuditEvidence {
+	if pb == nil {
+		return nil
+	}
+	return &AuditEvidence{
+		ID:              pb.EvidenceId,
+		SubjectAddress:  pb.SubjectAddress,
+		ReporterAddress: pb.ReporterAddress,
+		ActionID:        pb.ActionId,
+		EvidenceType:    pb.EvidenceType.String(),
+		ReportedHeight:  pb.ReportedHeight,
+		Metadata:        evidenceMetadataJSON(pb.EvidenceType, pb.Metadata),
+		RawMetadata:     pb.Metadata,
+	}
+}
+
+// AuditEvidenceListFromProto converts a list of proto evidence records.
+func AuditEvidenceListFromProto(pbs []audittypes.Evidence) []*AuditEvidence {
+	out := make([]*AuditEvidence, 0, len(pbs))
+	for i := range pbs {
+		out = append(out, AuditEvidenceFromProto(&pbs[i]))
+	}
+	return out
+}
+
+func evidenceMetadataJSON(t audittypes.EvidenceType, bz []byte) json.RawMessage {
+	var msg gogoproto.Message
+	switch t {
+	case audittypes.EvidenceType_EVIDENCE_TYPE_ACTION_FINALIZATION_SIGNATURE_FAILURE:
+		msg = &audittypes.ActionFinalizationSignatureFailureEvidenceMetadata{}
+	case audittypes.EvidenceType_EVIDENCE_TYPE_ACTION_FINALIZATION_NOT_IN_TOP_10:
+		msg = &audittypes.ActionFinalizationNotInTop10EvidenceMetadata{}
+	case audittypes.EvidenceType_EVIDENCE_TYPE_ACTION_EXPIRED:
+		msg = &audittypes.ActionExpiredEvidenceMetadata{}
+	case audittypes.EvidenceType_EVIDENCE_TYPE_STORAGE_CHALLENGE_FAILURE:
+		msg = &audittypes.StorageChallengeFailureEvidenceMetadata{}
+	case audittypes.EvidenceType_EVIDENCE_TYPE_CASCADE_CLIENT_FAILURE:
+		msg = &audittypes.CascadeClientFailureEvidenceMetadata{}
+	default:
+		return nil
+	}
+	if err := gogoproto.Unmarshal(bz, msg); err != nil {
+		return nil
+	}
+	var buf bytes.Buffer
+	if err := (&jsonpb.Marshaler{OrigName: true}).Marshal(&buf, msg); err != nil {
+		return nil
+	}
+	return buf.Bytes()
+}
+
+// EpochAnchorFromProto converts a proto epoch anchor to the SDK type.
+func EpochAnchorFromProto(pb *audittypes.EpochAnchor) *EpochAnchor {
+	if pb == nil {
+		return nil
+	}
+	return &EpochAnchor{
+		EpochInfo:               EpochInfo{ID: pb.EpochId, StartHeight: pb.EpochStartHeight, EndHeight: pb.EpochEndHeight},
+		LengthBlocks:            pb.EpochLengthBlocks,
+		Seed:                    pb.Seed,
+		ActiveSupernodeAccounts: pb.ActiveSupernodeAccounts,
+		TargetSupernodeAccounts: pb.TargetSupernodeAccounts,
+		ParamsCommitment:        pb.ParamsCommitment,
+		ActiveSetCommitment:     pb.ActiveSetCommitment,
+		TargetsSetCommitment:    pb.TargetsSetCommitment,
+	}
+}
+
+// HostReportFromProto converts a proto host report to the SDK type.
+func HostReportFromProto(pb audittypes.HostReport) HostReport {
+	return HostReport{
+		CPUUsagePercent:    pb.CpuUsagePercent,
+		MemUsagePercent:    pb.MemUsagePercent,
+		DiskUsagePercent:   pb.DiskUsagePercent,
+		InboundPortStates:  portStateNames(pb.InboundPortStates),
+		FailedActionsCount: pb.FailedActionsCount,
+	}
+}
+
+// EpochReportFromProto converts a proto epoch report to the SDK type.
+func EpochReportFromProto(pb *audittypes.EpochReport) *EpochReport {
+	if pb == nil {
+		return nil
+	}
+	r := &EpochReport{
+		SupernodeAccount: pb.SupernodeAccount,
+		EpochID:          pb.EpochId,
+		ReportHeight:     pb.ReportHeight,
+		HostReport:       HostReportFromProto(pb.HostReport),
+		Observations:     make([]StorageChallengeObservation, 0, len(pb.StorageChallengeObservations)),
+	}
+	for _, o := range pb.StorageChallengeObservations {
+		if o == nil {
+			continue
+		}
+		r.Observations = append(r.Observations, StorageChallengeObservation{
+			TargetSupernodeAccount: o.TargetSupernodeAccount,
+			PortStates:             portStateNames(o.PortStates),
+		})
+	}
+	return r
+}
+
+// StorageChallengeReportFromProto converts a proto storage challenge report.
+func StorageChallengeReportFromProto(pb audittypes.StorageChallengeReport) StorageChallengeReport {
+	return StorageChallengeReport{
+		ReporterSupernodeAccount: pb.ReporterSupernodeAccount,
+		EpochID:                  pb.EpochId,
+		ReportHeight:             pb.ReportHeight,
+		PortStates:               portStateNames(pb.PortStates),
+	}
+}
+
+// HostReportEntryFromProto converts a proto host report entry.
+func HostReportEntryFromProto(pb audittypes.HostReportEntry) HostReportEntry {
+	return HostReportEntry{
+		EpochID:      pb.EpochId,
+		ReportHeight: pb.ReportHeight,
+		HostReport:   HostReportFromProto(pb.HostReport),
+	}
+}
+
+func portStateNames(states []audittypes.PortState) []string {
+	out := make([]string, len(states))
+	for i, s := range states {
+		out[i] = s.String()
+	}
+	return out
+}
+
+// EvidenceResult contains the result of an evidence submission.
+type EvidenceResult struct {
+	TxHash     string
+	Height     int64
+	EvidenceID uint64
+}
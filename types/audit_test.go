@@ -0,0 +1,55 @@
+package types
+
+import (
+	"testing"
+
+	audittypes "github.com/LumeraProtocol/lumera/x/audit/v1/types"
+	gogoproto "github.com/cosmos/gogoproto/proto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditEvidenceFromProto(t *testing.T) {
+	meta, err := gogoproto.Marshal(&audittypes.StorageChallengeFailureEvidenceMetadata{
+		EpochId:                    7,
+		ChallengerSupernodeAccount: "lumera1challenger",
+		ChallengedSupernodeAccount: "lumera1target",
+		FailureType:                "timeout",
+	})
+	require.NoError(t, err)
+
+	ev := AuditEvidenceFromProto(&audittypes.Evidence{
+		EvidenceId:      3,
+		SubjectAddress:  "lumera1target",
+		ReporterAddress: "lumera1challenger",
+		EvidenceType:    audittypes.EvidenceType_EVIDENCE_TYPE_STORAGE_CHALLENGE_FAILURE,
+		Metadata:        meta,
+		ReportedHeight:  120,
+	})
+	require.Equal(t, uint64(3), ev.ID)
+	require.Equal(t, "EVIDENCE_TYPE_STORAGE_CHALLENGE_FAILURE", ev.EvidenceType)
+	require.JSONEq(t, `{"epoch_id":"7","challenger_supernode_account":"lumera1challenger","challenged_supernode_account":"lumera1target","failure_type":"timeout"}`, string(ev.Metadata))
+	require.Equal(t, meta, ev.RawMetadata)
+
+	unknown := AuditEvidenceFromProto(&audittypes.Evidence{Metadata: []byte{0xff}})
+	require.Nil(t, unknown.Metadata)
+	require.Nil(t, AuditEvidenceFromProto(nil))
+}
+
+func TestEpochReportFromProto(t *testing.T) {
+	r := EpochReportFromProto(&audittypes.EpochReport{
+		SupernodeAccount: "lumera1sn",
+		EpochId:          4,
+		ReportHeight:     400,
+		HostReport: audittypes.HostReport{
+			CpuUsagePercent:   12.5,
+			InboundPortStates: []audittypes.PortState{audittypes.PortState_PORT_STATE_OPEN, audittypes.PortState_PORT_STATE_CLOSED},
+		},
+		StorageChallengeObservations: []*audittypes.StorageChallengeObservation{
+			nil,
+			{TargetSupernodeAccount: "lumera1t", PortStates: []audittypes.PortState{audittypes.PortState_PORT_STATE_UNKNOWN}},
+		},
+	})
+	require.Equal(t, []string{"PORT_STATE_OPEN", "PORT_STATE_CLOSED"}, r.HostReport.InboundPortStates)
+	require.Equal(t, 12.5, r.HostReport.CPUUsagePercent)
+	require.Equal(t, []StorageChallengeObservation{{TargetSupernodeAccount: "lumera1t", PortStates: []string{"PORT_STATE_UNKNOWN"}}}, r.Observations)
+}
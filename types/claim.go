@@ -0,0 +1,48 @@
+package types
+
+import (
+	"time"
+
+	claimtypes "github.com/LumeraProtocol/lumera/x/claim/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// ClaimRecord is an old-chain balance that can be claimed on Lumera.
+type ClaimRecord struct {
+	OldAddress  string    `json:"old_address"`
+	Balance     sdk.Coins `json:"balance"`
+	Claimed     bool      `json:"claimed"`
+	ClaimTime   time.Time `json:"claim_time,omitempty"`
+	DestAddress string    `json:"dest_address,omitempty"`
+	VestedTier  uint32    `json:"vested_tier"`
+}
+
+// ClaimRecordFromProto converts a proto claim record to the SDK type.
+func ClaimRecordFromProto(pb *claimtypes.ClaimRecord) *ClaimRecord {
+	if pb == nil {
+		return nil
+	}
+	rec := &ClaimRecord{
+		OldAddress:  pb.OldAddress,
+		Balance:     pb.Balance,
+		Claimed:     pb.Claimed,
+		DestAddress: pb.DestAddress,
+		VestedTier:  pb.VestedTier,
+	}
+	if pb.ClaimTime > 0 {
+		rec.ClaimTime = time.Unix(pb.ClaimTime, 0).UTC()
+	}
+	return rec
+}
+
+// ClaimResult contains the result of a claim transaction
+type ClaimResult struct {
+	TxHash     string
+	Height     int64
+	OldAddress string
+	NewAddress string
+	// Amount is the balance transferred, taken from the claim event.
+	Amount sdk.Coins
+	// DelayedEndTime is the vesting end of a delayed claim (zero otherwise).
+	DelayedEndTime time.Time
+}
@@ -1,6 +1,9 @@
 package types
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
 	// ErrInvalidConfig is returned when configuration is invalid
@@ -17,4 +20,36 @@ var (
 
 	// ErrTaskFailed is returned when a task fails
 	ErrTaskFailed = errors.New("task failed")
+
+	// ErrInsufficientFunds is returned when an account cannot pay for an operation
+	ErrInsufficientFunds = errors.New("insufficient funds")
+
+	// ErrIncompatibleChain is returned when the chain runs module versions newer than the SDK supports
+	ErrIncompatibleChain = errors.New("incompatible chain version")
+
+	// ErrSpoolLimit is returned when an upload would exceed the temporary space budget
+	ErrSpoolLimit = errors.New("upload spool limit exceeded")
+
+	// ErrIntegrity is returned when downloaded data does not match its on-chain hash
+	ErrIntegrity = errors.New("integrity check failed")
+
+	// ErrTxFailed is returned when a transaction was included with a non-zero result code
+	ErrTxFailed = errors.New("transaction failed")
 )
+
+// IntegrityError reports a download whose content hash differs from the
+// action's CascadeMetadata.DataHash. It matches ErrIntegrity with errors.Is.
+type IntegrityError struct {
+	ActionID string
+	Path     string
+	Expected string // base64 blake3 from the action metadata
+	Actual   string // base64 blake3 of the downloaded data
+}
+
+func (e *IntegrityError) Error() string {
+	return fmt.Sprintf("action %s: data hash %s does not match expected %s", e.ActionID, e.Actual, e.Expected)
+}
+
+func (e *IntegrityError) Unwrap() error {
+	return ErrIntegrity
+}
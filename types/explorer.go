@@ -0,0 +1,79 @@
+package types
+
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Block is a committed block with its transactions decoded.
+type Block struct {
+	Height  int64
+	Hash    string // upper-case hex
+	Time    time.Time
+	ChainID string
+	// Proposer is the consensus address of the block proposer.
+	Proposer string
+	// Txs are decoded from the raw block data; they carry no execution results.
+	Txs []*Tx
+}
+
+// EventAttribute is one key/value pair of an Event.
+type EventAttribute struct {
+	Key   string
+	Value string
+}
+
+// Event is an ABCI event with its attributes in emission order.
+type Event struct {
+	Type       string
+	Attributes []EventAttribute
+}
+
+// Attribute returns the value of the first attribute named key.
+func (e Event) Attribute(key string) (string, bool) {
+	for _, a := range e.Attributes {
+		if a.Key == key {
+			return a.Value, true
+		}
+	}
+	return "", false
+}
+
+// TxResult is the execution result of a transaction.
+type TxResult struct {
+	Code      uint32
+	Codespace string
+	Log       string
+	GasWanted int64
+	GasUsed   int64
+	Events    []Event
+}
+
+// TxMessage is one message of a transaction. Msg is nil when the message type
+// is not registered with the SDK's interface registry.
+type TxMessage struct {
+	TypeURL string
+	Msg     sdk.Msg
+}
+
+// Tx is a decoded transaction. TxResult is only set when the transaction was
+// loaded through a tx query.
+type Tx struct {
+	Hash     string // upper-case hex
+	Height   int64
+	Time     time.Time
+	Memo     string
+	Fee      sdk.Coins
+	Messages []TxMessage
+	TxResult
+}
+
+// BlockResults holds the execution results of a block.
+type BlockResults struct {
+	Height int64
+	// TxResults are in block order, matching Block.Txs.
+	TxResults []TxResult
+	// FinalizeEvents are emitted outside transactions (begin/end block logic).
+	FinalizeEvents []Event
+}
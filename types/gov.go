@@ -0,0 +1,13 @@
+package types
+
+// ProposalResult contains the result of submitting a governance proposal.
+type ProposalResult struct {
+	TxHash     string
+	Height     int64
+	ProposalID uint64
+	// Status is the last observed proposal status (e.g.
+	// "PROPOSAL_STATUS_PASSED"); empty until the proposal is tracked.
+	Status string
+	// FailedReason is set by the chain when a passed proposal fails to execute.
+	FailedReason string
+}
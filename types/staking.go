@@ -0,0 +1,17 @@
+package types
+
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// StakingResult contains the result of a staking or distribution transaction.
+type StakingResult struct {
+	TxHash string
+	Height int64
+	// Amount is the amount undelegated or withdrawn, when the chain reports one.
+	Amount sdk.Coins
+	// CompletionTime is when an undelegation or redelegation matures.
+	CompletionTime time.Time
+}
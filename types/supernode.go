@@ -1,14 +1,120 @@
 package types
 
 import (
+	"fmt"
+	"sort"
+
 	supernodetypes "github.com/LumeraProtocol/lumera/x/supernode/v1/types"
 )
 
-// SuperNode represents a supernode in the SDK
+// SuperNode represents a supernode in the SDK.
+//
+// ValidatorAddress, IPAddress and State hold the latest snapshot; the history
+// slices keep every record reported by the chain, ordered by ascending height.
 type SuperNode struct {
-	ValidatorAddress string
-	IPAddress        string
-	State            string
+	ValidatorAddress string `json:"validator_address"`
+	SupernodeAccount string `json:"supernode_account"`
+	P2PPort          string `json:"p2p_port"`
+	Note             string `json:"note,omitempty"`
+
+	// Latest values derived from the histories ("" when the chain has none).
+	IPAddress   string `json:"ip_address"`
+	State       string `json:"state"`
+	StateHeight int64  `json:"state_height"`
+	StateReason string `json:"state_reason,omitempty"`
+
+	States         []SuperNodeStateRecord `json:"states"`
+	IPAddresses    []IPAddressRecord      `json:"ip_addresses"`
+	AccountHistory []AccountRecord        `json:"account_history"`
+	Evidence       []SuperNodeEvidence    `json:"evidence"`
+	Metrics        *MetricsAggregate      `json:"metrics,omitempty"`
+}
+
+// SuperNodeStateRecord is a state transition at a block height.
+type SuperNodeStateRecord struct {
+	State  string `json:"state"`
+	Height int64  `json:"height"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// IPAddressRecord is an IP address change at a block height.
+type IPAddressRecord struct {
+	Address string `json:"address"`
+	Height  int64  `json:"height"`
+}
+
+// AccountRecord is a supernode account change at a block height.
+type AccountRecord struct {
+	Account string `json:"account"`
+	Height  int64  `json:"height"`
+}
+
+// SuperNodeEvidence is misbehaviour evidence reported against a supernode.
+type SuperNodeEvidence struct {
+	ReporterAddress  string `json:"reporter_address"`
+	ValidatorAddress string `json:"validator_address"`
+	ActionID         string `json:"action_id,omitempty"`
+	EvidenceType     string `json:"evidence_type"`
+	Description      string `json:"description,omitempty"`
+	Severity         uint64 `json:"severity"`
+	Height           int32  `json:"height"`
+}
+
+// MetricsAggregate holds the aggregated metrics stored on the supernode record.
+type MetricsAggregate struct {
+	Values      map[string]float64 `json:"values"`
+	ReportCount uint64             `json:"report_count"`
+	Height      int64              `json:"height"`
+}
+
+// SuperNodeMetrics is the latest metrics report submitted by a supernode.
+type SuperNodeMetrics struct {
+	ValidatorAddress string       `json:"validator_address"`
+	ReportCount      uint64       `json:"report_count"`
+	Height           int64        `json:"height"`
+	Version          string       `json:"version"`
+	CPUCoresTotal    float64      `json:"cpu_cores_total"`
+	CPUUsagePercent  float64      `json:"cpu_usage_percent"`
+	MemTotalGB       float64      `json:"mem_total_gb"`
+	MemUsagePercent  float64      `json:"mem_usage_percent"`
+	MemFreeGB        float64      `json:"mem_free_gb"`
+	DiskTotalGB      float64      `json:"disk_total_gb"`
+	DiskUsagePercent float64      `json:"disk_usage_percent"`
+	DiskFreeGB       float64      `json:"disk_free_gb"`
+	UptimeSeconds    float64      `json:"uptime_seconds"`
+	PeersCount       uint32       `json:"peers_count"`
+	OpenPorts        []PortStatus `json:"open_ports"`
+}
+
+// PortStatus reports whether a supernode port is reachable.
+type PortStatus struct {
+	Port  uint32 `json:"port"`
+	State string `json:"state"`
+}
+
+// StateAt returns the state in effect at the given height, or "" if the
+// supernode had no state yet.
+func (s *SuperNode) StateAt(height int64) string {
+	state := ""
+	for _, r := range s.States {
+		if r.Height > height {
+			break
+		}
+		state = r.State
+	}
+	return state
+}
+
+// IPAddressAt returns the IP address in effect at the given height.
+func (s *SuperNode) IPAddressAt(height int64) string {
+	addr := ""
+	for _, r := range s.IPAddresses {
+		if r.Height > height {
+			break
+		}
+		addr = r.Address
+	}
+	return addr
 }
 
 // GetLatestIPAddress extracts the IP address with the highest height from IPAddressHistory
@@ -17,59 +123,170 @@ func GetLatestIPAddress(history []*supernodetypes.IPAddressHistory) string {
 		return ""
 	}
 
-	latestIdx := 0
-	maxHeight := history[0].Height
+	latestIdx := -1
+	var maxHeight int64
 
-	for i := 1; i < len(history); i++ {
-		if history[i].Height > maxHeight {
-			maxHeight = history[i].Height
+	for i, h := range history {
+		if h == nil {
+			continue
+		}
+		if latestIdx < 0 || h.Height > maxHeight {
+			maxHeight = h.Height
 			latestIdx = i
 		}
 	}
+	if latestIdx < 0 {
+		return ""
+	}
 
 	return history[latestIdx].Address
 }
 
 // GetLatestState extracts the state with the highest height from SuperNodeStateRecord
 func GetLatestState(states []*supernodetypes.SuperNodeStateRecord) *supernodetypes.SuperNodeState {
-	if len(states) == 0 {
-		return nil
+	if rec := latestStateRecord(states); rec != nil {
+		return &rec.State
 	}
+	return nil
+}
 
-	latestIdx := 0
-	maxHeight := states[0].Height
-
-	for i := 1; i < len(states); i++ {
-		if states[i].Height > maxHeight {
-			maxHeight = states[i].Height
-			latestIdx = i
+func latestStateRecord(states []*supernodetypes.SuperNodeStateRecord) *supernodetypes.SuperNodeStateRecord {
+	var latest *supernodetypes.SuperNodeStateRecord
+	for _, s := range states {
+		if s == nil {
+			continue
+		}
+		if latest == nil || s.Height > latest.Height {
+			latest = s
 		}
 	}
-
-	return &states[latestIdx].State
+	return latest
 }
 
-// SuperNodeFromProto converts a proto supernode to SDK supernode
+// SuperNodeFromProto converts a proto supernode to SDK supernode. It only
+// returns nil for a nil input; missing histories yield empty latest values.
 func SuperNodeFromProto(pb *supernodetypes.SuperNode) *SuperNode {
 	if pb == nil {
 		return nil
 	}
 
-	// Extract the IP address with the highest height from PrevIpAddresses
-	ipAddress := GetLatestIPAddress(pb.PrevIpAddresses)
-	if ipAddress == "" {
-		return nil
+	sn := &SuperNode{
+		ValidatorAddress: pb.ValidatorAddress,
+		SupernodeAccount: pb.SupernodeAccount,
+		P2PPort:          pb.P2PPort,
+		Note:             pb.Note,
+		IPAddress:        GetLatestIPAddress(pb.PrevIpAddresses),
+		States:           make([]SuperNodeStateRecord, 0, len(pb.States)),
+		IPAddresses:      make([]IPAddressRecord, 0, len(pb.PrevIpAddresses)),
+		AccountHistory:   make([]AccountRecord, 0, len(pb.PrevSupernodeAccounts)),
+		Evidence:         make([]SuperNodeEvidence, 0, len(pb.Evidence)),
 	}
 
-	// Extract the state with the highest height from States
-	latestState := GetLatestState(pb.States)
-	if latestState == nil {
-		return nil
+	if latest := latestStateRecord(pb.States); latest != nil {
+		sn.State = latest.State.String()
+		sn.StateHeight = latest.Height
+		sn.StateReason = latest.Reason
+	}
+
+	for _, s := range pb.States {
+		if s == nil {
+			continue
+		}
+		sn.States = append(sn.States, SuperNodeStateRecord{State: s.State.String(), Height: s.Height, Reason: s.Reason})
+	}
+	sort.SliceStable(sn.States, func(i, j int) bool { return sn.States[i].Height < sn.States[j].Height })
+
+	for _, ip := range pb.PrevIpAddresses {
+		if ip == nil {
+			continue
+		}
+		sn.IPAddresses = append(sn.IPAddresses, IPAddressRecord{Address: ip.Address, Height: ip.Height})
+	}
+	sort.SliceStable(sn.IPAddresses, func(i, j int) bool { return sn.IPAddresses[i].Height < sn.IPAddresses[j].Height })
+
+	for _, acc := range pb.PrevSupernodeAccounts {
+		if acc == nil {
+			continue
+		}
+		sn.AccountHistory = append(sn.AccountHistory, AccountRecord{Account: acc.Account, Height: acc.Height})
+	}
+	sort.SliceStable(sn.AccountHistory, func(i, j int) bool { return sn.AccountHistory[i].Height < sn.AccountHistory[j].Height })
+
+	for _, ev := range pb.Evidence {
+		if ev == nil {
+			continue
+		}
+		sn.Evidence = append(sn.Evidence, SuperNodeEvidence{
+			ReporterAddress:  ev.ReporterAddress,
+			ValidatorAddress: ev.ValidatorAddress,
+			ActionID:         ev.ActionId,
+			EvidenceType:     ev.EvidenceType,
+			Description:      ev.Description,
+			Severity:         ev.Severity,
+			Height:           ev.Height,
+		})
+	}
+
+	if pb.Metrics != nil {
+		values := make(map[string]float64, len(pb.Metrics.Metrics))
+		for k, v := range pb.Metrics.Metrics {
+			values[k] = v
+		}
+		sn.Metrics = &MetricsAggregate{
+			Values:      values,
+			ReportCount: pb.Metrics.ReportCount,
+			Height:      pb.Metrics.Height,
+		}
 	}
 
-	return &SuperNode{
+	return sn
+}
+
+// SuperNodesFromProto converts a list of proto supernodes, skipping nil entries
+// so the result never contains nil holes.
+func SuperNodesFromProto(pbs []*supernodetypes.SuperNode) []*SuperNode {
+	sns := make([]*SuperNode, 0, len(pbs))
+	for _, pb := range pbs {
+		if sn := SuperNodeFromProto(pb); sn != nil {
+			sns = append(sns, sn)
+		}
+	}
+	return sns
+}
+
+// SuperNodeMetricsFromProto converts a proto metrics state to SDK metrics.
+func SuperNodeMetricsFromProto(pb *supernodetypes.SupernodeMetricsState) *SuperNodeMetrics {
+	if pb == nil {
+		return nil
+	}
+	m := &SuperNodeMetrics{
 		ValidatorAddress: pb.ValidatorAddress,
-		IPAddress:        ipAddress,
-		State:            latestState.String(),
+		ReportCount:      pb.ReportCount,
+		Height:           pb.Height,
+	}
+	if r := pb.Metrics; r != nil {
+		m.Version = formatVersion(r.VersionMajor, r.VersionMinor, r.VersionPatch)
+		m.CPUCoresTotal = r.CpuCoresTotal
+		m.CPUUsagePercent = r.CpuUsagePercent
+		m.MemTotalGB = r.MemTotalGb
+		m.MemUsagePercent = r.MemUsagePercent
+		m.MemFreeGB = r.MemFreeGb
+		m.DiskTotalGB = r.DiskTotalGb
+		m.DiskUsagePercent = r.DiskUsagePercent
+		m.DiskFreeGB = r.DiskFreeGb
+		m.UptimeSeconds = r.UptimeSeconds
+		m.PeersCount = r.PeersCount
+		m.OpenPorts = make([]PortStatus, 0, len(r.OpenPorts))
+		for _, p := range r.OpenPorts {
+			m.OpenPorts = append(m.OpenPorts, PortStatus{Port: p.Port, State: p.State.String()})
+		}
+	}
+	return m
+}
+
+func formatVersion(major, minor, patch uint32) string {
+	if major == 0 && minor == 0 && patch == 0 {
+		return ""
 	}
+	return fmt.Sprintf("v%d.%d.%d", major, minor, patch)
 }
@@ -0,0 +1,64 @@
+package types
+
+import (
+	"testing"
+
+	supernodetypes "github.com/LumeraProtocol/lumera/x/supernode/v1/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSuperNodeFromProto(t *testing.T) {
+	pb := &supernodetypes.SuperNode{
+		ValidatorAddress: "lumeravaloper1abc",
+		SupernodeAccount: "lumera1sn",
+		P2PPort:          "4445",
+		Note:             "primary",
+		States: []*supernodetypes.SuperNodeStateRecord{
+			{State: supernodetypes.SuperNodeStateStopped, Height: 30, Reason: "maintenance"},
+			{State: supernodetypes.SuperNodeStateActive, Height: 10},
+			nil,
+		},
+		PrevIpAddresses: []*supernodetypes.IPAddressHistory{
+			{Address: "10.0.0.2", Height: 20},
+			{Address: "10.0.0.1", Height: 10},
+		},
+		PrevSupernodeAccounts: []*supernodetypes.SupernodeAccountHistory{{Account: "lumera1sn", Height: 10}},
+		Evidence:              []*supernodetypes.Evidence{{ReporterAddress: "r", EvidenceType: "missed", Severity: 2, Height: 25}},
+		Metrics:               &supernodetypes.MetricsAggregate{Metrics: map[string]float64{"cpu": 0.5}, ReportCount: 3, Height: 28},
+	}
+
+	sn := SuperNodeFromProto(pb)
+	require.NotNil(t, sn)
+	require.Equal(t, "10.0.0.2", sn.IPAddress)
+	require.Equal(t, supernodetypes.SuperNodeStateStopped.String(), sn.State)
+	require.Equal(t, int64(30), sn.StateHeight)
+	require.Equal(t, "maintenance", sn.StateReason)
+	require.Equal(t, "4445", sn.P2PPort)
+	require.Equal(t, "primary", sn.Note)
+
+	require.Len(t, sn.States, 2)
+	require.Equal(t, int64(10), sn.States[0].Height)
+	require.Equal(t, supernodetypes.SuperNodeStateActive.String(), sn.StateAt(25))
+	require.Equal(t, "", sn.StateAt(5))
+	require.Equal(t, "10.0.0.1", sn.IPAddressAt(15))
+	require.Len(t, sn.AccountHistory, 1)
+	require.Len(t, sn.Evidence, 1)
+	require.Equal(t, uint64(2), sn.Evidence[0].Severity)
+	require.Equal(t, 0.5, sn.Metrics.Values["cpu"])
+}
+
+func TestSuperNodesFromProtoNoHoles(t *testing.T) {
+	sns := SuperNodesFromProto([]*supernodetypes.SuperNode{
+		{ValidatorAddress: "a"},
+		nil,
+		{ValidatorAddress: "b", States: []*supernodetypes.SuperNodeStateRecord{{State: supernodetypes.SuperNodeStateActive, Height: 1}}},
+	})
+	require.Len(t, sns, 2)
+	for _, sn := range sns {
+		require.NotNil(t, sn)
+	}
+	require.Equal(t, "", sns[0].IPAddress)
+	require.Equal(t, "", sns[0].State)
+	require.Empty(t, sns[0].States)
+	require.Equal(t, supernodetypes.SuperNodeStateActive.String(), sns[1].State)
+}